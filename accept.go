@@ -0,0 +1,92 @@
+package contenttype
+
+import (
+	"sort"
+)
+
+// MediaRange is a media range of the Accept header with its quality value and accept extension parameters.
+type MediaRange struct {
+	MediaType           MediaType
+	Quality             float64
+	ExtensionParameters Parameters
+	// Order is the position of the media range in the Accept header.
+	Order int
+}
+
+// AcceptList is a parsed Accept header.
+type AcceptList []MediaRange
+
+// ParseAccept parses the given Accept header value and returns its media ranges in the original order.
+// If the header value cannot be parsed an appropriate error is returned.
+func ParseAccept(headerValue string) (AcceptList, error) {
+	// RFC 7231, 5.3.2. Accept
+	var acceptList AcceptList
+
+	err := forEachMediaRange(headerValue, func(mediaRange MediaType, weight uint, extensionParameters string, order uint) {
+		acceptList = append(acceptList, MediaRange{
+			MediaType:           mediaRange,
+			Quality:             getQualityValue(weight),
+			ExtensionParameters: parseParameters(extensionParameters),
+			Order:               int(order),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return acceptList, nil
+}
+
+// Sort sorts the media ranges by their precedence: more specific ranges come first (a concrete type before a
+// subtype wildcard before */*, more parameters before fewer), then ranges with higher quality values, and finally
+// ranges listed earlier in the header.
+func (acceptList AcceptList) Sort() {
+	// RFC 7231, 5.3.2. Accept
+	sort.SliceStable(acceptList, func(i, j int) bool {
+		a, b := acceptList[i], acceptList[j]
+
+		if specificityA, specificityB := getRangeSpecificity(a.MediaType, a.MediaType), getRangeSpecificity(b.MediaType, b.MediaType); specificityA != specificityB {
+			return specificityA > specificityB
+		}
+
+		if len(a.MediaType.Parameters) != len(b.MediaType.Parameters) {
+			return len(a.MediaType.Parameters) > len(b.MediaType.Parameters)
+		}
+
+		if a.Quality != b.Quality {
+			return a.Quality > b.Quality
+		}
+
+		return a.Order < b.Order
+	})
+}
+
+// Quality returns the quality value the most specific matching media range assigns to the media type,
+// or 0 if no media range matches it.
+func (acceptList AcceptList) Quality(mediaType MediaType) float64 {
+	// RFC 7231, 5.3.2. Accept
+	index := acceptList.match(mediaType)
+	if index == -1 {
+		return 0
+	}
+
+	return acceptList[index].Quality
+}
+
+// match returns the index of the most specific media range matching the media type or -1 if none matches.
+func (acceptList AcceptList) match(mediaType MediaType) int {
+	result := -1
+	for i, mediaRange := range acceptList {
+		var current MediaType
+		if result != -1 {
+			current = acceptList[result].MediaType
+		}
+
+		if compareMediaTypes(mediaRange.MediaType, mediaType, false) &&
+			getPrecedence(mediaRange.MediaType, current, mediaType) {
+			result = i
+		}
+	}
+
+	return result
+}
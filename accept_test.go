@@ -0,0 +1,131 @@
+package contenttype_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestParseAccept(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		result contenttype.AcceptList
+	}{
+		{name: "Empty header", header: "", result: nil},
+		{name: "Single range", header: "application/json", result: contenttype.AcceptList{
+			{MediaType: contenttype.NewMediaType("application/json"), Quality: 1, ExtensionParameters: nil, Order: 0},
+		}},
+		{name: "Weighted ranges", header: "text/html, application/xml;q=0.9, */*;q=0.8", result: contenttype.AcceptList{
+			{MediaType: contenttype.NewMediaType("text/html"), Quality: 1, ExtensionParameters: nil, Order: 0},
+			{MediaType: contenttype.NewMediaType("application/xml"), Quality: 0.9, ExtensionParameters: nil, Order: 1},
+			{MediaType: contenttype.NewMediaType("*/*"), Quality: 0.8, ExtensionParameters: nil, Order: 2},
+		}},
+		{name: "Parameters and extension parameters", header: "text/plain;format=flowed;q=0.5;ext=1", result: contenttype.AcceptList{
+			{MediaType: contenttype.NewMediaType("text/plain;format=flowed"), Quality: 0.5, ExtensionParameters: contenttype.Parameters{"ext": "1"}, Order: 0},
+		}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := contenttype.ParseAccept(testCase.header)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if !reflect.DeepEqual(result, testCase.result) {
+				t.Errorf("Invalid accept list, got %v, expected %v for %s", result, testCase.result, testCase.header)
+			}
+		})
+	}
+}
+
+func TestParseAcceptErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "Type only", header: "text", err: contenttype.ErrInvalidMediaType},
+		{name: "Invalid weight", header: "text/plain;q=2", err: contenttype.ErrInvalidWeight},
+		{name: "Missing comma", header: "text/plain text/html", err: contenttype.ErrInvalidMediaRange},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := contenttype.ParseAccept(testCase.header)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+		})
+	}
+}
+
+func TestAcceptListSort(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		result []string
+	}{
+		{name: "Specificity", header: "*/*, text/*, text/plain;format=flowed, text/plain", result: []string{"text/plain;format=flowed", "text/plain", "text/*", "*/*"}},
+		{name: "Quality", header: "a/a;q=0.5, a/b, a/c;q=0.8", result: []string{"a/b", "a/c", "a/a"}},
+		{name: "Specificity before quality", header: "*/*, a/*;q=0.5, a/b;q=0.1", result: []string{"a/b", "a/*", "*/*"}},
+		{name: "Suffix wildcard", header: "application/*, application/*+json, application/json", result: []string{"application/json", "application/*+json", "application/*"}},
+		{name: "Original order", header: "a/b, a/a, a/c", result: []string{"a/b", "a/a", "a/c"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			acceptList, err := contenttype.ParseAccept(testCase.header)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %s", err, testCase.header)
+			}
+
+			acceptList.Sort()
+
+			result := make([]string, len(acceptList))
+			for i, mediaRange := range acceptList {
+				result[i] = mediaRange.MediaType.String()
+			}
+
+			if !reflect.DeepEqual(result, testCase.result) {
+				t.Errorf("Invalid order, got %v, expected %v for %s", result, testCase.result, testCase.header)
+			}
+		})
+	}
+}
+
+func TestAcceptListQuality(t *testing.T) {
+	acceptList, err := contenttype.ParseAccept("text/*;q=0.5, text/html, text/plain;format=flowed;q=0.2, */*;q=0.1, image/png;q=0")
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	testCases := []struct {
+		name      string
+		mediaType contenttype.MediaType
+		result    float64
+	}{
+		{name: "Exact match", mediaType: contenttype.NewMediaType("text/html"), result: 1},
+		{name: "Subtype wildcard", mediaType: contenttype.NewMediaType("text/css"), result: 0.5},
+		{name: "Parameter match", mediaType: contenttype.NewMediaType("text/plain;format=flowed"), result: 0.2},
+		{name: "Parameter mismatch", mediaType: contenttype.NewMediaType("text/plain"), result: 0.5},
+		{name: "Wildcard", mediaType: contenttype.NewMediaType("application/json"), result: 0.1},
+		{name: "Excluded", mediaType: contenttype.NewMediaType("image/png"), result: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := acceptList.Quality(testCase.mediaType); result != testCase.result {
+				t.Errorf("Invalid quality, got %v, expected %v for %s", result, testCase.result, testCase.mediaType)
+			}
+		})
+	}
+
+	var emptyList contenttype.AcceptList
+	if result := emptyList.Quality(contenttype.NewMediaType("text/html")); result != 0 {
+		t.Errorf("Invalid quality, got %v, expected 0 for an empty list", result)
+	}
+}
@@ -0,0 +1,92 @@
+package contenttype
+
+import (
+	"html"
+	"net/http"
+	"strings"
+)
+
+// Alternate is a localized variant of a resource available at the given URL.
+type Alternate struct {
+	Language Language
+	URL      string
+}
+
+// AddAlternateLinks adds a Link header field for every alternate to the header.
+// If the default URL is not empty, an x-default entry pointing to it is added as well.
+// Alternates without a language are skipped, and the characters that can't appear in a URI reference (e.g. whitespace
+// or '>') are percent-encoded in the URLs.
+func AddAlternateLinks(header http.Header, alternates []Alternate, defaultURL string) {
+	// RFC 8288, 3. Link Serialisation in HTTP Headers
+	for _, alternate := range alternates {
+		if len(alternate.Language.Language) > 0 {
+			header.Add("Link", formatAlternateLink(alternate.URL, alternate.Language.String()))
+		}
+	}
+
+	if len(defaultURL) > 0 {
+		header.Add("Link", formatAlternateLink(defaultURL, "x-default"))
+	}
+}
+
+// AlternateLinkElements returns an HTML <link> element for every alternate, one per line.
+// If the default URL is not empty, an x-default element pointing to it is added as well.
+// Alternates without a language are skipped.
+func AlternateLinkElements(alternates []Alternate, defaultURL string) string {
+	var stringBuilder strings.Builder
+
+	for _, alternate := range alternates {
+		if len(alternate.Language.Language) > 0 {
+			writeAlternateLinkElement(&stringBuilder, alternate.URL, alternate.Language.String())
+		}
+	}
+
+	if len(defaultURL) > 0 {
+		writeAlternateLinkElement(&stringBuilder, defaultURL, "x-default")
+	}
+
+	return stringBuilder.String()
+}
+
+func formatAlternateLink(url, hreflang string) string {
+	return "<" + escapeLinkURL(url) + `>; rel="alternate"; hreflang="` + hreflang + `"`
+}
+
+// escapeLinkURL percent-encodes the characters that can't appear in a URI reference, so that the URL can't end the
+// URI reference of a Link header field value early. The reserved characters (e.g. ',' and ';') are kept as they are,
+// because they are part of the URL and encoding them would change its meaning.
+func escapeLinkURL(url string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var stringBuilder strings.Builder
+
+	for i := 0; i < len(url); i++ {
+		c := url[i]
+		switch {
+		// RFC 3986, 2. Characters
+		case c <= ' ' || c >= 0x7F, c == '<', c == '>', c == '"', c == '\\', c == '^', c == '`', c == '{', c == '|', c == '}':
+			if stringBuilder.Len() == 0 {
+				stringBuilder.WriteString(url[:i])
+			}
+			stringBuilder.WriteByte('%')
+			stringBuilder.WriteByte(hexDigits[c>>4])
+			stringBuilder.WriteByte(hexDigits[c&0x0F])
+		case stringBuilder.Len() > 0:
+			stringBuilder.WriteByte(c)
+		}
+	}
+
+	if stringBuilder.Len() == 0 {
+		return url
+	}
+
+	return stringBuilder.String()
+}
+
+func writeAlternateLinkElement(stringBuilder *strings.Builder, url, hreflang string) {
+	stringBuilder.WriteString(`<link rel="alternate" hreflang="`)
+	stringBuilder.WriteString(html.EscapeString(hreflang))
+	stringBuilder.WriteString(`" href="`)
+	stringBuilder.WriteString(html.EscapeString(url))
+	stringBuilder.WriteString("\">\n")
+}
@@ -0,0 +1,88 @@
+package contenttype_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+var alternates = []contenttype.Alternate{
+	{Language: contenttype.NewLanguage("de-ch"), URL: "https://example.com/de-ch/"},
+	{Language: contenttype.NewLanguage("en"), URL: "https://example.com/en/?a=1&b=2"},
+}
+
+func TestAddAlternateLinks(t *testing.T) {
+	testCases := []struct {
+		name       string
+		alternates []contenttype.Alternate
+		defaultURL string
+		result     []string
+	}{
+		{name: "No alternates", alternates: nil, defaultURL: "", result: nil},
+		{name: "Alternates", alternates: alternates, defaultURL: "", result: []string{
+			`<https://example.com/de-ch/>; rel="alternate"; hreflang="de-CH"`,
+			`<https://example.com/en/?a=1&b=2>; rel="alternate"; hreflang="en"`,
+		}},
+		{name: "Alternates and default", alternates: alternates, defaultURL: "https://example.com/", result: []string{
+			`<https://example.com/de-ch/>; rel="alternate"; hreflang="de-CH"`,
+			`<https://example.com/en/?a=1&b=2>; rel="alternate"; hreflang="en"`,
+			`<https://example.com/>; rel="alternate"; hreflang="x-default"`,
+		}},
+		{name: "Case normalization", alternates: []contenttype.Alternate{
+			{Language: contenttype.Language{Language: "DE", Region: "ch"}, URL: "https://example.com/de-ch/"},
+			{Language: contenttype.Language{Language: "ZH", Script: "hANT", Region: "tw"}, URL: "https://example.com/zh-tw/"},
+		}, defaultURL: "", result: []string{
+			`<https://example.com/de-ch/>; rel="alternate"; hreflang="de-CH"`,
+			`<https://example.com/zh-tw/>; rel="alternate"; hreflang="zh-Hant-TW"`,
+		}},
+		{name: "Empty language", alternates: []contenttype.Alternate{
+			{Language: contenttype.Language{}, URL: "https://example.com/unknown/"},
+		}, defaultURL: "", result: nil},
+		{name: "Escaped URL", alternates: []contenttype.Alternate{
+			{Language: contenttype.NewLanguage("en"), URL: "https://x/a>b,c;d e\"f\u00fc{g}"},
+		}, defaultURL: "https://x/<default>", result: []string{
+			`<https://x/a%3Eb,c;d%20e%22f%C3%BC%7Bg%7D>; rel="alternate"; hreflang="en"`,
+			`<https://x/%3Cdefault%3E>; rel="alternate"; hreflang="x-default"`,
+		}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			header := http.Header{}
+			contenttype.AddAlternateLinks(header, testCase.alternates, testCase.defaultURL)
+
+			if result := header.Values("Link"); !reflect.DeepEqual(result, testCase.result) {
+				t.Errorf("Invalid Link header, got %v, expected %v", result, testCase.result)
+			}
+		})
+	}
+}
+
+func TestAlternateLinkElements(t *testing.T) {
+	testCases := []struct {
+		name       string
+		alternates []contenttype.Alternate
+		defaultURL string
+		result     string
+	}{
+		{name: "No alternates", alternates: nil, defaultURL: "", result: ""},
+		{name: "Alternates and default", alternates: alternates, defaultURL: "https://example.com/", result: "" +
+			"<link rel=\"alternate\" hreflang=\"de-CH\" href=\"https://example.com/de-ch/\">\n" +
+			"<link rel=\"alternate\" hreflang=\"en\" href=\"https://example.com/en/?a=1&amp;b=2\">\n" +
+			"<link rel=\"alternate\" hreflang=\"x-default\" href=\"https://example.com/\">\n"},
+		{name: "Case normalization and empty language", alternates: []contenttype.Alternate{
+			{Language: contenttype.Language{Language: "DE", Region: "ch"}, URL: "https://example.com/de-ch/"},
+			{Language: contenttype.Language{}, URL: "https://example.com/unknown/"},
+		}, defaultURL: "", result: "<link rel=\"alternate\" hreflang=\"de-CH\" href=\"https://example.com/de-ch/\">\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := contenttype.AlternateLinkElements(testCase.alternates, testCase.defaultURL); result != testCase.result {
+				t.Errorf("Invalid elements, got %s, expected %s", result, testCase.result)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package contenttype
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// AcceptCache memoizes the results of GetAcceptableMediaTypeFromHeader for the most recently used Accept header values.
+// The cached results belong to one list of available media types, all the entries are discarded when a different
+// list is passed. It is safe for concurrent use.
+type AcceptCache struct {
+	hits   uint64 // accessed atomically, kept first for 64-bit alignment
+	misses uint64
+
+	mutex               sync.Mutex
+	capacity            int
+	availableMediaTypes []MediaType
+	generation          uint64
+	entries             map[string]*list.Element
+	recent              *list.List // front is the most recently used entry
+}
+
+type acceptCacheEntry struct {
+	headerValue         string
+	index               int // index of the acceptable media type in the available media types
+	extensionParameters Parameters
+	err                 error
+}
+
+// NewAcceptCache creates an AcceptCache holding at most capacity Accept header values.
+// A cache with a capacity less than one does not store any results.
+func NewAcceptCache(capacity int) *AcceptCache {
+	return &AcceptCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		recent:   list.New(),
+	}
+}
+
+// GetAcceptableMediaType chooses a media type from available media types according to the Accept header like
+// GetAcceptableMediaType does, reusing the cached result for the header value if there is one.
+func (cache *AcceptCache) GetAcceptableMediaType(request *http.Request, availableMediaTypes []MediaType) (MediaType, Parameters, error) {
+	// RFC 7231, 5.3.2. Accept
+	if len(availableMediaTypes) == 0 {
+		return MediaType{}, nil, ErrNoAvailableTypeGiven
+	}
+
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+	if !found {
+		return availableMediaTypes[0], nil, nil
+	}
+
+	return cache.GetAcceptableMediaTypeFromHeader(acceptHeader, availableMediaTypes)
+}
+
+// GetAcceptableMediaTypeFromHeader chooses a media type from available media types according to the specified Accept
+// header value like GetAcceptableMediaTypeFromHeader does, reusing the cached result for the header value if there is
+// one. The parameters of the available media types must not be modified in place while their results are cached.
+func (cache *AcceptCache) GetAcceptableMediaTypeFromHeader(headerValue string, availableMediaTypes []MediaType) (MediaType, Parameters, error) {
+	if len(availableMediaTypes) == 0 {
+		return MediaType{}, nil, ErrNoAvailableTypeGiven
+	}
+
+	cache.mutex.Lock()
+	if !equalMediaTypes(cache.availableMediaTypes, availableMediaTypes) {
+		cache.purge()
+		cache.availableMediaTypes = append([]MediaType(nil), availableMediaTypes...)
+	}
+	generation := cache.generation
+
+	if element, found := cache.entries[headerValue]; found {
+		cache.recent.MoveToFront(element)
+		entry := element.Value.(*acceptCacheEntry)
+		cache.mutex.Unlock()
+		atomic.AddUint64(&cache.hits, 1)
+
+		if entry.err != nil {
+			return MediaType{}, nil, entry.err
+		}
+
+		return availableMediaTypes[entry.index], copyParameters(entry.extensionParameters), nil
+	}
+	cache.mutex.Unlock()
+	atomic.AddUint64(&cache.misses, 1)
+
+	result, extensionParameters, err := GetAcceptableMediaTypeFromHeader(headerValue, availableMediaTypes)
+
+	entry := &acceptCacheEntry{headerValue: headerValue, index: -1, err: err}
+	if err == nil {
+		for i, availableMediaType := range availableMediaTypes {
+			if availableMediaType.Equal(result) {
+				entry.index = i
+				break
+			}
+		}
+		entry.extensionParameters = copyParameters(extensionParameters)
+	}
+
+	cache.mutex.Lock()
+	// the available media types could have changed while the header value was negotiated
+	if cache.generation == generation && cache.capacity > 0 {
+		cache.add(entry)
+	}
+	cache.mutex.Unlock()
+
+	return result, extensionParameters, err
+}
+
+// Hits returns the number of lookups answered from the cache.
+func (cache *AcceptCache) Hits() uint64 {
+	return atomic.LoadUint64(&cache.hits)
+}
+
+// Misses returns the number of lookups that had to negotiate the header value.
+func (cache *AcceptCache) Misses() uint64 {
+	return atomic.LoadUint64(&cache.misses)
+}
+
+// Len returns the number of cached header values.
+func (cache *AcceptCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.recent.Len()
+}
+
+// Purge discards all the cached results.
+func (cache *AcceptCache) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.purge()
+}
+
+func (cache *AcceptCache) purge() {
+	cache.generation++
+	cache.entries = map[string]*list.Element{}
+	cache.recent.Init()
+}
+
+func (cache *AcceptCache) add(entry *acceptCacheEntry) {
+	if element, found := cache.entries[entry.headerValue]; found {
+		element.Value = entry
+		cache.recent.MoveToFront(element)
+		return
+	}
+
+	cache.entries[entry.headerValue] = cache.recent.PushFront(entry)
+
+	for cache.recent.Len() > cache.capacity {
+		oldest := cache.recent.Back()
+		cache.recent.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*acceptCacheEntry).headerValue)
+	}
+}
+
+func equalMediaTypes(a, b []MediaType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyParameters(parameters Parameters) Parameters {
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	result := make(Parameters, len(parameters))
+	for key, value := range parameters {
+		result[key] = value
+	}
+
+	return result
+}
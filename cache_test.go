@@ -0,0 +1,220 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestAcceptCache(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+		contenttype.NewMediaType("text/html"),
+	}
+
+	testCases := []struct {
+		name                string
+		header              string
+		result              contenttype.MediaType
+		extensionParameters contenttype.Parameters
+	}{
+		{name: "Exact type", header: "application/xml", result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Weights", header: "text/html;q=0.5, application/xml;q=0.8", result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Extension parameters", header: "text/html;q=1;a=b", result: contenttype.NewMediaType("text/html"), extensionParameters: contenttype.Parameters{"a": "b"}},
+		{name: "Wildcard", header: "*/*", result: contenttype.NewMediaType("application/json"), extensionParameters: nil},
+	}
+
+	cache := contenttype.NewAcceptCache(10)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// the second lookup is answered from the cache
+			for i := 0; i < 2; i++ {
+				result, extensionParameters, err := cache.GetAcceptableMediaTypeFromHeader(testCase.header, availableMediaTypes)
+				if err != nil {
+					t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+				} else if !result.Equal(testCase.result) {
+					t.Errorf("Invalid content type, got %s, expected %s for %s", result, testCase.result, testCase.header)
+				} else if !reflect.DeepEqual(extensionParameters, testCase.extensionParameters) {
+					t.Errorf("Wrong extension parameters, got %v, expected %v for %s", extensionParameters, testCase.extensionParameters, testCase.header)
+				}
+			}
+		})
+	}
+
+	if hits, misses := cache.Hits(), cache.Misses(); hits != uint64(len(testCases)) || misses != uint64(len(testCases)) {
+		t.Errorf("Invalid counters, got %d hits and %d misses, expected %d of each", hits, misses, len(testCases))
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+	if result, _, err := cache.GetAcceptableMediaType(request, availableMediaTypes); err != nil {
+		t.Errorf("Unexpected error \"%v\" for a request without the Accept header", err)
+	} else if !result.Equal(availableMediaTypes[0]) {
+		t.Errorf("Invalid content type, got %s, expected %s for a request without the Accept header", result, availableMediaTypes[0])
+	}
+
+	request.Header.Set("Accept", "text/html")
+	if result, _, err := cache.GetAcceptableMediaType(request, availableMediaTypes); err != nil {
+		t.Errorf("Unexpected error \"%v\" for %s", err, request.Header.Get("Accept"))
+	} else if !result.Equal(availableMediaTypes[2]) {
+		t.Errorf("Invalid content type, got %s, expected %s for %s", result, availableMediaTypes[2], request.Header.Get("Accept"))
+	}
+}
+
+func TestAcceptCacheErrors(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+	}
+
+	testCases := []struct {
+		name   string
+		header string
+		err    error
+	}{
+		{name: "No acceptable type", header: "application/xml", err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Invalid header", header: "application/", err: contenttype.ErrInvalidMediaType},
+	}
+
+	cache := contenttype.NewAcceptCache(10)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				_, _, err := cache.GetAcceptableMediaTypeFromHeader(testCase.header, availableMediaTypes)
+				if err == nil {
+					t.Errorf("Expected an error for %s", testCase.header)
+				} else if !errors.Is(err, testCase.err) {
+					t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+				}
+			}
+		})
+	}
+
+	if _, _, err := cache.GetAcceptableMediaTypeFromHeader("application/json", nil); !errors.Is(err, contenttype.ErrNoAvailableTypeGiven) {
+		t.Errorf("Unexpected error \"%v\", expected \"%v\"", err, contenttype.ErrNoAvailableTypeGiven)
+	}
+}
+
+func TestAcceptCacheEviction(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+	}
+
+	cache := contenttype.NewAcceptCache(2)
+
+	for _, header := range []string{"application/json", "application/xml", "application/json", "*/*", "application/json", "application/xml"} {
+		if _, _, err := cache.GetAcceptableMediaTypeFromHeader(header, availableMediaTypes); err != nil {
+			t.Fatalf("Unexpected error \"%v\" for %s", err, header)
+		}
+	}
+
+	// "application/xml" is evicted by "*/*" as the least recently used header value
+	if hits, misses := cache.Hits(), cache.Misses(); hits != 2 || misses != 4 {
+		t.Errorf("Invalid counters, got %d hits and %d misses, expected 2 hits and 4 misses", hits, misses)
+	}
+
+	if length := cache.Len(); length != 2 {
+		t.Errorf("Invalid length, got %d, expected 2", length)
+	}
+
+	cache.Purge()
+	if length := cache.Len(); length != 0 {
+		t.Errorf("Invalid length, got %d, expected 0", length)
+	}
+
+	disabledCache := contenttype.NewAcceptCache(0)
+	for i := 0; i < 2; i++ {
+		if _, _, err := disabledCache.GetAcceptableMediaTypeFromHeader("application/json", availableMediaTypes); err != nil {
+			t.Fatalf("Unexpected error \"%v\"", err)
+		}
+	}
+
+	if hits, length := disabledCache.Hits(), disabledCache.Len(); hits != 0 || length != 0 {
+		t.Errorf("Invalid disabled cache, got %d hits and length %d, expected 0", hits, length)
+	}
+}
+
+func TestAcceptCacheInvalidation(t *testing.T) {
+	cache := contenttype.NewAcceptCache(10)
+
+	result, _, err := cache.GetAcceptableMediaTypeFromHeader("application/*", []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	} else if result.String() != "application/json" {
+		t.Errorf("Invalid content type, got %s, expected application/json", result)
+	}
+
+	result, _, err = cache.GetAcceptableMediaTypeFromHeader("application/*", []contenttype.MediaType{
+		contenttype.NewMediaType("application/xml"),
+		contenttype.NewMediaType("application/json"),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	} else if result.String() != "application/xml" {
+		t.Errorf("Invalid content type, got %s, expected application/xml", result)
+	}
+
+	if hits, misses := cache.Hits(), cache.Misses(); hits != 0 || misses != 2 {
+		t.Errorf("Invalid counters, got %d hits and %d misses, expected 0 hits and 2 misses", hits, misses)
+	}
+}
+
+func TestAcceptCacheConcurrency(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+	}
+
+	cache := contenttype.NewAcceptCache(1)
+	headers := []struct {
+		header  string
+		subtype string
+	}{
+		{header: "application/xml, application/json;q=0.5", subtype: "xml"},
+		{header: "application/json, application/xml;q=0.5", subtype: "json"},
+	}
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+
+			for j := 0; j < 100; j++ {
+				header := headers[(i+j)%len(headers)]
+				result, _, err := cache.GetAcceptableMediaTypeFromHeader(header.header, availableMediaTypes)
+				if err != nil || result.Subtype != header.subtype {
+					t.Errorf("Invalid result %s, error \"%v\" for %s", result, err, header.header)
+					return
+				}
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+
+	if total := cache.Hits() + cache.Misses(); total != 800 {
+		t.Errorf("Invalid number of lookups, got %d, expected 800", total)
+	}
+}
+
+func BenchmarkAcceptCache(b *testing.B) {
+	cache := contenttype.NewAcceptCache(16)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := cache.GetAcceptableMediaTypeFromHeader(benchmarkAcceptHeaders[0].header, benchmarkMediaTypes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package contenttype
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Messages represents a message bundle as a key-message map.
+type Messages = map[string]string
+
+// Catalog holds message bundles for multiple languages.
+// A Catalog is meant to be filled once (e.g. with LoadCatalog) and read-only afterwards: Translate and MissingKeys
+// can be called from multiple goroutines simultaneously, but Add must not run concurrently with any other method.
+type Catalog struct {
+	bundles map[LanguageID]Messages
+}
+
+// NewCatalog creates an empty Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		bundles: map[LanguageID]Messages{},
+	}
+}
+
+// LoadCatalog loads message bundles from the JSON files in the given directory.
+// Every file must be named after the language tag of its bundle (e.g. de-CH.json) and contain an object
+// mapping message keys to messages. Files without the .json extension are ignored.
+func LoadCatalog(directory string) (*Catalog, error) {
+	// ioutil instead of os.ReadDir and os.ReadFile, which need Go 1.16, the module supports Go 1.14 (see go.mod)
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog()
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		language, err := ParseLanguage(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name(), ErrInvalidLanguage)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(directory, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		messages := Messages{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name(), err)
+		}
+
+		catalog.Add(language, messages)
+	}
+
+	return catalog, nil
+}
+
+// Add adds the messages to the bundle of the given language, replacing messages with the same keys.
+func (catalog *Catalog) Add(language Language, messages Messages) {
+	id := language.ID()
+	bundle, found := catalog.bundles[id]
+	if !found {
+		bundle = Messages{}
+		catalog.bundles[id] = bundle
+	}
+
+	for key, message := range messages {
+		bundle[key] = message
+	}
+}
+
+// Translate looks up the message with the given key for the language and replaces its positional placeholders
+// ({0}, {1}, ...) with the arguments.
+// If the bundle of the language does not contain the key, less specific languages are tried (e.g. de-Latn-CH,
+// de-CH, de-Latn, de). Returns ErrMessageNotFound if none of them contains the key.
+func (catalog *Catalog) Translate(key string, language Language, arguments ...interface{}) (string, error) {
+	for _, fallback := range getFallbackLanguages(language) {
+		if message, found := catalog.bundles[fallback.ID()][key]; found {
+			return formatMessage(message, arguments), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s for %s: %w", key, language, ErrMessageNotFound)
+}
+
+// MissingKeys returns the keys that are present in some bundle of the catalog but missing from the others.
+// The result maps every language tag that has missing keys to the sorted list of its missing keys.
+func (catalog *Catalog) MissingKeys() map[string][]string {
+	allKeys := map[string]struct{}{}
+	for _, bundle := range catalog.bundles {
+		for key := range bundle {
+			allKeys[key] = struct{}{}
+		}
+	}
+
+	result := map[string][]string{}
+	for id, bundle := range catalog.bundles {
+		var missingKeys []string
+		for key := range allKeys {
+			if _, found := bundle[key]; !found {
+				missingKeys = append(missingKeys, key)
+			}
+		}
+
+		if len(missingKeys) > 0 {
+			sort.Strings(missingKeys)
+			result[id.String()] = missingKeys
+		}
+	}
+
+	return result
+}
+
+// getFallbackLanguages returns the language followed by its less specific forms. Unlike the Lookup scheme of
+// RFC 4647, 3.4, which removes the subtags from the end (de-Latn-CH, de-Latn, de), the region is kept before the
+// script (de-Latn-CH, de-CH, de-Latn, de): bundles are rarely tagged with a script, and a request for de-Latn-CH
+// should get the Swiss bundle rather than the generic German one.
+func getFallbackLanguages(language Language) []Language {
+	candidates := []Language{
+		language,
+		{Language: language.Language, Script: language.Script, Region: language.Region},
+		{Language: language.Language, Region: language.Region},
+		{Language: language.Language, Script: language.Script},
+		{Language: language.Language},
+	}
+
+	result := make([]Language, 0, len(candidates))
+	for i, candidate := range candidates {
+		duplicate := false
+		for _, previous := range candidates[:i] {
+			if candidate == previous {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
+
+func formatMessage(message string, arguments []interface{}) string {
+	if len(arguments) == 0 {
+		return message
+	}
+
+	var stringBuilder strings.Builder
+
+	for len(message) > 0 {
+		start := strings.IndexByte(message, '{')
+		if start == -1 {
+			break
+		}
+
+		end := start + 1
+		index := 0
+		for ; end < len(message) && isDigitChar(message[end]); end++ {
+			if index <= len(arguments) {
+				index = index*10 + int(message[end]-'0')
+			}
+		}
+
+		if end == start+1 || end >= len(message) || message[end] != '}' || index >= len(arguments) {
+			// not a placeholder, copy the brace as is
+			stringBuilder.WriteString(message[:start+1])
+			message = message[start+1:]
+			continue
+		}
+
+		stringBuilder.WriteString(message[:start])
+		stringBuilder.WriteString(fmt.Sprint(arguments[index]))
+		message = message[end+1:]
+	}
+
+	stringBuilder.WriteString(message)
+
+	return stringBuilder.String()
+}
@@ -0,0 +1,157 @@
+package contenttype_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	directory, err := ioutil.TempDir("", "catalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	files := map[string]string{
+		"en.json":    `{"greeting": "Hello, {0}!", "farewell": "Goodbye"}`,
+		"de.json":    `{"greeting": "Hallo, {0}!", "farewell": "Tschüss"}`,
+		"de-CH.json": `{"greeting": "Grüezi, {0}!"}`,
+		"notes.txt":  `ignored`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(directory, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, err := contenttype.LoadCatalog(directory)
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	testCases := []struct {
+		name     string
+		key      string
+		language string
+		result   string
+	}{
+		{name: "Exact language", key: "greeting", language: "de-CH", result: "Grüezi, Anna!"},
+		{name: "Fallback to language", key: "farewell", language: "de-CH", result: "Tschüss"},
+		{name: "Fallback from script and region", key: "greeting", language: "de-Latn-CH", result: "Grüezi, Anna!"},
+		{name: "Fallback from region", key: "greeting", language: "en-GB", result: "Hello, Anna!"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := catalog.Translate(testCase.key, contenttype.NewLanguage(testCase.language), "Anna")
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.language)
+			} else if result != testCase.result {
+				t.Errorf("Invalid message, got %s, expected %s for %s", result, testCase.result, testCase.language)
+			}
+		})
+	}
+
+	missingKeys := map[string][]string{"de-CH": {"farewell"}}
+	if result := catalog.MissingKeys(); !reflect.DeepEqual(result, missingKeys) {
+		t.Errorf("Wrong missing keys, got %v, expected %v", result, missingKeys)
+	}
+}
+
+func TestLoadCatalogErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		file    string
+		content string
+		err     error
+	}{
+		{name: "Invalid language", file: "xx-YY.json", content: `{}`, err: contenttype.ErrInvalidLanguage},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			directory, err := ioutil.TempDir("", "catalog")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(directory)
+
+			if err := ioutil.WriteFile(filepath.Join(directory, testCase.file), []byte(testCase.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = contenttype.LoadCatalog(directory)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.file)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.file)
+			}
+		})
+	}
+}
+
+func TestCatalogTranslate(t *testing.T) {
+	catalog := contenttype.NewCatalog()
+	catalog.Add(contenttype.NewLanguage("en"), contenttype.Messages{
+		"plain":    "No placeholders",
+		"swapped":  "{1} before {0}",
+		"braces":   "{not a placeholder} {5} {0}",
+		"repeated": "{0}{0}",
+	})
+
+	testCases := []struct {
+		name      string
+		key       string
+		arguments []interface{}
+		result    string
+	}{
+		{name: "No placeholders", key: "plain", result: "No placeholders"},
+		{name: "Swapped placeholders", key: "swapped", arguments: []interface{}{"a", 2}, result: "2 before a"},
+		{name: "Non-placeholder braces", key: "braces", arguments: []interface{}{"x"}, result: "{not a placeholder} {5} x"},
+		{name: "Repeated placeholder", key: "repeated", arguments: []interface{}{"ab"}, result: "abab"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := catalog.Translate(testCase.key, contenttype.NewLanguage("en-US"), testCase.arguments...)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.key)
+			} else if result != testCase.result {
+				t.Errorf("Invalid message, got %s, expected %s for %s", result, testCase.result, testCase.key)
+			}
+		})
+	}
+
+	if _, err := catalog.Translate("unknown", contenttype.NewLanguage("en"), nil); !errors.Is(err, contenttype.ErrMessageNotFound) {
+		t.Errorf("Unexpected error \"%v\", expected \"%v\"", err, contenttype.ErrMessageNotFound)
+	}
+}
+
+func TestCatalogTranslateOverflowLanguages(t *testing.T) {
+	catalog := contenttype.NewCatalog()
+	catalog.Add(contenttype.NewLanguage("en"), contenttype.Messages{"greeting": "Hello"})
+	catalog.Add(contenttype.NewLanguage("sl-rozaj"), contenttype.Messages{"greeting": "Zdravo"})
+
+	if result, err := catalog.Translate("greeting", contenttype.NewLanguage("sl-rozaj")); err != nil {
+		t.Errorf("Unexpected error \"%v\"", err)
+	} else if result != "Zdravo" {
+		t.Errorf("Invalid message, got %s, expected Zdravo", result)
+	}
+
+	// languages that can't be packed into an ID fall back to the packed ones
+	for i := 0; i < 100; i++ {
+		language := contenttype.NewLanguage(fmt.Sprintf("en-x%05d", i))
+		if result, err := catalog.Translate("greeting", language); err != nil {
+			t.Errorf("Unexpected error \"%v\" for %s", err, language)
+		} else if result != "Hello" {
+			t.Errorf("Invalid message, got %s, expected Hello for %s", result, language)
+		}
+	}
+}
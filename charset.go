@@ -0,0 +1,74 @@
+package contenttype
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GetAcceptableCharset chooses a charset from available charsets according to the Accept-Charset header.
+// If the request does not contain the Accept-Charset header, the first available charset is returned.
+// Returns the most suitable charset or an error if no charset can be selected.
+func GetAcceptableCharset(request *http.Request, availableCharsets []string) (string, error) {
+	// RFC 7231, 5.3.3. Accept-Charset
+	if len(availableCharsets) == 0 {
+		return "", ErrNoAvailableCharsetGiven
+	}
+
+	acceptCharsetHeader, found := getListHeader(request.Header, "Accept-Charset")
+	if !found {
+		return availableCharsets[0], nil
+	}
+
+	return GetAcceptableCharsetFromHeader(acceptCharsetHeader, availableCharsets)
+}
+
+// GetAcceptableCharsetFromHeader chooses a charset from available charsets according to the specified Accept-Charset
+// header value. Charset names are compared case-insensitively and "*" matches every charset not listed explicitly.
+// Returns the most suitable charset or an error if no charset can be selected.
+func GetAcceptableCharsetFromHeader(headerValue string, availableCharsets []string) (string, error) {
+	// RFC 7231, 5.3.3. Accept-Charset
+	acceptableCharsets, err := parseWeightedTokens(headerValue, ErrInvalidCharset)
+	if err != nil {
+		return "", err
+	}
+
+	resultIndex := -1
+	resultWeight := uint(0)
+	resultOrder := 0
+	for i, availableCharset := range availableCharsets {
+		weight, order, found := getTokenWeight(acceptableCharsets, strings.ToLower(availableCharset))
+		if !found || weight == 0 {
+			continue
+		}
+
+		if resultIndex == -1 || weight > resultWeight || (weight == resultWeight && order < resultOrder) {
+			resultIndex = i
+			resultWeight = weight
+			resultOrder = order
+		}
+	}
+
+	if resultIndex == -1 {
+		return "", ErrNoAcceptableCharsetFound
+	}
+
+	return availableCharsets[resultIndex], nil
+}
+
+// getTokenWeight returns the weight and the position of the token in the list, falling back to the "*" element.
+func getTokenWeight(weightedTokens []weightedToken, token string) (weight uint, order int, found bool) {
+	wildcardIndex := -1
+	for i, weightedToken := range weightedTokens {
+		if weightedToken.token == token {
+			return weightedToken.weight, i, true
+		} else if weightedToken.token == "*" && wildcardIndex == -1 {
+			wildcardIndex = i
+		}
+	}
+
+	if wildcardIndex == -1 {
+		return 0, 0, false
+	}
+
+	return weightedTokens[wildcardIndex].weight, wildcardIndex, true
+}
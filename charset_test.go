@@ -0,0 +1,110 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestGetAcceptableCharset(t *testing.T) {
+	testCases := []struct {
+		name              string
+		header            string
+		availableCharsets []string
+		result            string
+	}{
+		{name: "Empty header", header: "", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "utf-8"},
+		{name: "Single charset", header: "iso-8859-1", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "iso-8859-1"},
+		{name: "Case-insensitive charset", header: "ISO-8859-1", availableCharsets: []string{"utf-8", "Iso-8859-1"}, result: "Iso-8859-1"},
+		{name: "Weights", header: "utf-8;q=0.5, iso-8859-1;q=0.7", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "iso-8859-1"},
+		{name: "Equal weights", header: "windows-1252, utf-8", availableCharsets: []string{"utf-8", "windows-1252"}, result: "windows-1252"},
+		{name: "Wildcard", header: "*", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "utf-8"},
+		{name: "Wildcard with exclusion", header: "*, utf-8;q=0", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "iso-8859-1"},
+		{name: "Explicit charset before wildcard", header: "*;q=0.1, iso-8859-1;q=0.5", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "iso-8859-1"},
+		{name: "Spaces around weight", header: "utf-8 ; q=0.2 , iso-8859-1 ; q=0.1", availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "utf-8"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept-Charset", testCase.header)
+			}
+
+			result, err := contenttype.GetAcceptableCharset(request, testCase.availableCharsets)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if result != testCase.result {
+				t.Errorf("Invalid charset, got %s, expected %s for %s", result, testCase.result, testCase.header)
+			}
+		})
+	}
+}
+
+func TestGetAcceptableCharsetMultipleHeaders(t *testing.T) {
+	testCases := []struct {
+		name              string
+		headers           []string
+		availableCharsets []string
+		result            string
+	}{
+		{name: "Charset in second field line", headers: []string{"iso-8859-1", "utf-8"}, availableCharsets: []string{"utf-8"}, result: "utf-8"},
+		{name: "Higher weight in second field line", headers: []string{"utf-8;q=0.5", "iso-8859-1"}, availableCharsets: []string{"utf-8", "iso-8859-1"}, result: "iso-8859-1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			for _, header := range testCase.headers {
+				request.Header.Add("Accept-Charset", header)
+			}
+
+			result, err := contenttype.GetAcceptableCharset(request, testCase.availableCharsets)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %v", err, testCase.headers)
+			} else if result != testCase.result {
+				t.Errorf("Invalid charset, got %s, expected %s for %v", result, testCase.result, testCase.headers)
+			}
+		})
+	}
+}
+
+func TestGetAcceptableCharsetErrors(t *testing.T) {
+	testCases := []struct {
+		name              string
+		header            string
+		availableCharsets []string
+		err               error
+	}{
+		{name: "No available charset", header: "", availableCharsets: nil, err: contenttype.ErrNoAvailableCharsetGiven},
+		{name: "No acceptable charset", header: "iso-8859-1", availableCharsets: []string{"utf-8"}, err: contenttype.ErrNoAcceptableCharsetFound},
+		{name: "Excluded charset", header: "utf-8;q=0", availableCharsets: []string{"utf-8"}, err: contenttype.ErrNoAcceptableCharsetFound},
+		{name: "Excluded wildcard", header: "*;q=0", availableCharsets: []string{"utf-8"}, err: contenttype.ErrNoAcceptableCharsetFound},
+		{name: "Invalid charset", header: "utf-8, /", availableCharsets: []string{"utf-8"}, err: contenttype.ErrInvalidCharset},
+		{name: "Trailing comma", header: "utf-8,", availableCharsets: []string{"utf-8"}, err: contenttype.ErrInvalidCharset},
+		{name: "Invalid weight", header: "utf-8;q=2", availableCharsets: []string{"utf-8"}, err: contenttype.ErrInvalidWeight},
+		{name: "Invalid parameter", header: "utf-8;a=b", availableCharsets: []string{"utf-8"}, err: contenttype.ErrInvalidParameter},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept-Charset", testCase.header)
+			}
+
+			_, err := contenttype.GetAcceptableCharset(request, testCase.availableCharsets)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+		})
+	}
+}
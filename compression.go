@@ -0,0 +1,315 @@
+package contenttype
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionOptions are the options of the compression handler.
+type CompressionOptions struct {
+	// MinSize is the minimal size of the response body in bytes for it to be compressed.
+	MinSize int
+	// Level is the gzip and deflate compression level, zero means flate.DefaultCompression.
+	Level int
+	// ExcludedMediaTypes lists the media types (e.g. the already compressed ones) that are never compressed.
+	ExcludedMediaTypes []MediaType
+}
+
+// DefaultCompressionOptions returns the options used by CompressHandler: responses of at least 1024 bytes are
+// compressed unless their media type is a compressed image, audio, video, font or archive format.
+func DefaultCompressionOptions() CompressionOptions {
+	return CompressionOptions{
+		MinSize: 1024,
+		Level:   flate.DefaultCompression,
+		ExcludedMediaTypes: []MediaType{
+			// the image formats are listed one by one, image/svg+xml is text and compresses well
+			NewMediaType("image/png"),
+			NewMediaType("image/jpeg"),
+			NewMediaType("image/gif"),
+			NewMediaType("image/webp"),
+			NewMediaType("image/avif"),
+			NewMediaType("image/heic"),
+			NewMediaType("image/heif"),
+			NewMediaType("image/jxl"),
+			NewMediaType("audio/*"),
+			NewMediaType("video/*"),
+			NewMediaType("font/woff"),
+			NewMediaType("font/woff2"),
+			NewMediaType("application/gzip"),
+			NewMediaType("application/x-gzip"),
+			NewMediaType("application/zip"),
+			NewMediaType("application/zstd"),
+			NewMediaType("application/x-bzip2"),
+			NewMediaType("application/x-7z-compressed"),
+			NewMediaType("application/x-rar-compressed"),
+		},
+	}
+}
+
+// CompressHandler returns a handler that compresses the responses of the given handler with gzip or deflate
+// according to the Accept-Encoding header of the request using the DefaultCompressionOptions.
+func CompressHandler(handler http.Handler) http.Handler {
+	return CompressHandlerWithOptions(handler, DefaultCompressionOptions())
+}
+
+// CompressHandlerWithOptions returns a handler that compresses the responses of the given handler with gzip or
+// deflate according to the Accept-Encoding header of the request.
+// Responses that already have a Content-Encoding, have no body, are smaller than MinSize or have one of the
+// ExcludedMediaTypes are sent as is. Compressed responses get the Content-Encoding header, their Content-Length
+// header is removed and strong entity tags are turned into weak ones. Vary: Accept-Encoding is added (unless Vary
+// already lists it or is "*") to every response that could have been compressed, including the 304 (Not Modified) responses and the responses to HEAD
+// requests, which also get the Content-Encoding of the matching GET response.
+func CompressHandlerWithOptions(handler http.Handler, options CompressionOptions) http.Handler {
+	if options.Level == 0 {
+		options.Level = flate.DefaultCompression
+	}
+
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		compressionWriter := &compressionResponseWriter{
+			ResponseWriter: responseWriter,
+			request:        request,
+			options:        options,
+		}
+		defer compressionWriter.close()
+
+		handler.ServeHTTP(compressionWriter, request)
+	})
+}
+
+type compressionResponseWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	options     CompressionOptions
+	statusCode  int
+	wroteHeader bool
+	started     bool
+	buffer      []byte
+	compressor  io.WriteCloser
+}
+
+func (writer *compressionResponseWriter) WriteHeader(statusCode int) {
+	if writer.wroteHeader {
+		return
+	}
+
+	// RFC 7231, 6.2. Informational 1xx
+	if statusCode >= 100 && statusCode < http.StatusOK {
+		// interim responses (e.g. 103 Early Hints) precede the final one and have no body
+		writer.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	writer.statusCode = statusCode
+	writer.wroteHeader = true
+
+	if !statusHasBody(statusCode) {
+		writer.start()
+	}
+}
+
+func (writer *compressionResponseWriter) Write(data []byte) (int, error) {
+	if !writer.wroteHeader {
+		writer.WriteHeader(http.StatusOK)
+	}
+
+	if writer.started {
+		if writer.compressor != nil {
+			return writer.compressor.Write(data)
+		}
+		return writer.ResponseWriter.Write(data)
+	}
+
+	writer.buffer = append(writer.buffer, data...)
+	if len(writer.buffer) >= writer.options.MinSize {
+		if err := writer.start(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush sends the buffered data to the client, the response is compressed only if it already reached MinSize.
+func (writer *compressionResponseWriter) Flush() {
+	if !writer.wroteHeader {
+		writer.WriteHeader(http.StatusOK)
+	}
+
+	if !writer.started {
+		if err := writer.start(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := writer.compressor.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the caller take over the connection if the underlying response writer supports it.
+func (writer *compressionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := writer.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	writer.started = true
+	return hijacker.Hijack()
+}
+
+func (writer *compressionResponseWriter) start() error {
+	writer.started = true
+
+	header := writer.Header()
+
+	if writer.compressible(header) {
+		addVaryFieldName(header, "Accept-Encoding")
+
+		// RFC 7231, 4.3.2. HEAD
+		// the response to a HEAD request has the header fields of the response to GET, without its body
+		head := writer.request.Method == http.MethodHead
+		size := len(writer.buffer)
+		if head {
+			if contentLength, err := strconv.Atoi(header.Get("Content-Length")); err == nil && contentLength > size {
+				size = contentLength
+			}
+		}
+
+		// RFC 7232, 4.1. 304 Not Modified
+		// a 304 response only needs the Vary header of the response it stands for
+		if writer.statusCode != http.StatusNotModified && size >= writer.options.MinSize {
+			encoding := EncodingIdentity
+			if acceptEncodingHeader, found := getListHeader(writer.request.Header, "Accept-Encoding"); found {
+				availableEncodings := []string{EncodingGzip, EncodingDeflate, EncodingIdentity}
+				if acceptableEncoding, err := GetAcceptableEncodingFromHeader(acceptEncodingHeader, availableEncodings); err == nil {
+					encoding = acceptableEncoding
+				}
+			}
+
+			if encoding != EncodingIdentity {
+				if head {
+					writer.buffer = nil // the body of the response to a HEAD request is never sent
+				} else {
+					compressor, err := newCompressor(writer.ResponseWriter, encoding, writer.options.Level)
+					if err != nil {
+						return err
+					}
+					writer.compressor = compressor
+				}
+
+				header.Set("Content-Encoding", encoding)
+				header.Del("Content-Length")
+				header.Del("Accept-Ranges")
+
+				// RFC 7232, 2.1. Weak versus Strong
+				if etag := header.Get("ETag"); len(etag) > 0 && !strings.HasPrefix(etag, "W/") {
+					header.Set("ETag", "W/"+etag)
+				}
+			}
+		}
+	}
+
+	if writer.wroteHeader {
+		writer.ResponseWriter.WriteHeader(writer.statusCode)
+	}
+
+	if len(writer.buffer) == 0 {
+		return nil
+	}
+
+	buffer := writer.buffer
+	writer.buffer = nil
+
+	var err error
+	if writer.compressor != nil {
+		_, err = writer.compressor.Write(buffer)
+	} else {
+		_, err = writer.ResponseWriter.Write(buffer)
+	}
+
+	return err
+}
+
+func (writer *compressionResponseWriter) compressible(header http.Header) bool {
+	if writer.statusCode == http.StatusNoContent || len(header.Get("Content-Encoding")) > 0 {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if len(contentType) == 0 {
+		if len(writer.buffer) == 0 {
+			// the content type of 304 responses and responses to HEAD requests can't be sniffed,
+			// but the response they stand for could have been compressed
+			return writer.statusCode == http.StatusNotModified || writer.request.Method == http.MethodHead
+		}
+
+		// sniff the content type before the body is compressed
+		contentType = http.DetectContentType(writer.buffer)
+		header.Set("Content-Type", contentType)
+	}
+
+	mediaType, err := ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return !mediaType.MatchesAny(writer.options.ExcludedMediaTypes...)
+}
+
+func (writer *compressionResponseWriter) close() {
+	if !writer.started {
+		if err := writer.start(); err != nil {
+			return
+		}
+	}
+
+	if writer.compressor != nil {
+		writer.compressor.Close()
+	}
+}
+
+func newCompressor(w io.Writer, encoding string, level int) (io.WriteCloser, error) {
+	switch encoding {
+	case EncodingGzip:
+		return gzip.NewWriterLevel(w, level)
+	case EncodingDeflate:
+		// RFC 7230, 4.2.2. Deflate Coding
+		return zlib.NewWriterLevel(w, level)
+	default:
+		return nil, nil
+	}
+}
+
+func statusHasBody(statusCode int) bool {
+	// RFC 7230, 3.3.3. Message Body Length
+	return statusCode >= http.StatusOK &&
+		statusCode != http.StatusNoContent &&
+		statusCode != http.StatusNotModified
+}
+
+// addVaryFieldName adds the field name to the Vary header unless it is already listed or the header is "*".
+func addVaryFieldName(header http.Header, fieldName string) {
+	// RFC 7231, 7.1.4. Vary
+	for _, value := range header.Values("Vary") {
+		for _, listedFieldName := range strings.Split(value, ",") {
+			if listedFieldName = strings.TrimSpace(listedFieldName); listedFieldName == "*" || strings.EqualFold(listedFieldName, fieldName) {
+				return
+			}
+		}
+	}
+
+	header.Add("Vary", fieldName)
+}
@@ -0,0 +1,255 @@
+package contenttype_test
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+// getVaryFieldNames returns the number of times every field name is listed in the Vary header.
+func getVaryFieldNames(header http.Header) map[string]int {
+	result := map[string]int{}
+	for _, value := range header.Values("Vary") {
+		for _, fieldName := range strings.Split(value, ",") {
+			if fieldName = strings.ToLower(strings.TrimSpace(fieldName)); len(fieldName) > 0 {
+				result[fieldName]++
+			}
+		}
+	}
+
+	return result
+}
+
+func TestCompressHandler(t *testing.T) {
+	largeBody := strings.Repeat("Hello, world! ", 100)
+	smallBody := "Hello, world!"
+
+	testCases := []struct {
+		name            string
+		method          string
+		acceptEncoding  string
+		contentType     string
+		contentEncoding string
+		etag            string
+		varyHeader      string
+		statusCode      int
+		body            string
+		encoding        string
+		vary            bool
+		resultETag      string
+	}{
+		{name: "Gzip", acceptEncoding: "gzip, deflate", contentType: "text/plain", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Deflate", acceptEncoding: "deflate", contentType: "text/plain", body: largeBody, encoding: "deflate", vary: true},
+		{name: "Preferred deflate", acceptEncoding: "gzip;q=0.5, deflate", contentType: "application/json", body: largeBody, encoding: "deflate", vary: true},
+		{name: "Unsupported encoding", acceptEncoding: "br", contentType: "text/plain", body: largeBody, encoding: "", vary: true},
+		{name: "Excluded gzip", acceptEncoding: "gzip;q=0", contentType: "text/plain", body: largeBody, encoding: "", vary: true},
+		{name: "No Accept-Encoding", contentType: "text/plain", body: largeBody, encoding: "", vary: true},
+		{name: "Small body", acceptEncoding: "gzip", contentType: "text/plain", body: smallBody, encoding: "", vary: true},
+		{name: "Sniffed content type", acceptEncoding: "gzip", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Compressed media type", acceptEncoding: "gzip", contentType: "image/png", body: largeBody, encoding: "", vary: false},
+		{name: "SVG image", acceptEncoding: "gzip", contentType: "image/svg+xml", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Archive media type", acceptEncoding: "gzip", contentType: "application/zip", body: largeBody, encoding: "", vary: false},
+		{name: "Already encoded", acceptEncoding: "gzip", contentType: "text/plain", contentEncoding: "br", body: largeBody, encoding: "br", vary: false},
+		{name: "Strong ETag", acceptEncoding: "gzip", contentType: "text/plain", etag: "\"abc\"", body: largeBody, encoding: "gzip", vary: true, resultETag: "W/\"abc\""},
+		{name: "Weak ETag", acceptEncoding: "gzip", contentType: "text/plain", etag: "W/\"abc\"", body: largeBody, encoding: "gzip", vary: true, resultETag: "W/\"abc\""},
+		{name: "Uncompressed ETag", acceptEncoding: "br", contentType: "text/plain", etag: "\"abc\"", body: largeBody, encoding: "", vary: true, resultETag: "\"abc\""},
+		{name: "Existing Vary", acceptEncoding: "gzip", contentType: "text/plain", varyHeader: "Origin", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Vary listing Accept-Encoding", acceptEncoding: "gzip", contentType: "text/plain", varyHeader: "accept-encoding, Origin", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Vary wildcard", acceptEncoding: "gzip", contentType: "text/plain", varyHeader: "*", body: largeBody, encoding: "gzip", vary: false},
+		{name: "No content", acceptEncoding: "gzip", contentType: "text/plain", statusCode: http.StatusNoContent, encoding: "", vary: false},
+		{name: "Not modified", acceptEncoding: "gzip", contentType: "text/plain", etag: "\"abc\"", statusCode: http.StatusNotModified, encoding: "", vary: true, resultETag: "\"abc\""},
+		{name: "Not modified without content type", acceptEncoding: "gzip", statusCode: http.StatusNotModified, encoding: "", vary: true},
+		{name: "Head request", method: http.MethodHead, acceptEncoding: "gzip", contentType: "text/plain", encoding: "gzip", vary: true},
+		{name: "Head request with body", method: http.MethodHead, acceptEncoding: "gzip", contentType: "text/plain", body: largeBody, encoding: "gzip", vary: true},
+		{name: "Head request with status", method: http.MethodHead, acceptEncoding: "gzip", contentType: "text/plain", statusCode: http.StatusOK, encoding: "gzip", vary: true},
+		{name: "Head request without Accept-Encoding", method: http.MethodHead, contentType: "text/plain", encoding: "", vary: true},
+		{name: "Head request with excluded media type", method: http.MethodHead, acceptEncoding: "gzip", contentType: "image/png", encoding: "", vary: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			method := testCase.method
+			if len(method) == 0 {
+				method = http.MethodGet
+			}
+
+			request := httptest.NewRequest(method, "http://test.test", nil)
+			if len(testCase.acceptEncoding) > 0 {
+				request.Header.Set("Accept-Encoding", testCase.acceptEncoding)
+			}
+
+			handler := contenttype.CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(testCase.contentType) > 0 {
+					w.Header().Set("Content-Type", testCase.contentType)
+				}
+				if len(testCase.contentEncoding) > 0 {
+					w.Header().Set("Content-Encoding", testCase.contentEncoding)
+				}
+				if len(testCase.etag) > 0 {
+					w.Header().Set("ETag", testCase.etag)
+				}
+				if len(testCase.varyHeader) > 0 {
+					w.Header().Set("Vary", testCase.varyHeader)
+				}
+				w.Header().Set("Content-Length", "1400")
+				if testCase.statusCode != 0 {
+					w.WriteHeader(testCase.statusCode)
+				}
+				if len(testCase.body) > 0 {
+					// write in chunks to exercise buffering
+					io.WriteString(w, testCase.body[:len(testCase.body)/2])
+					io.WriteString(w, testCase.body[len(testCase.body)/2:])
+				}
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			response := recorder.Result()
+
+			if encoding := response.Header.Get("Content-Encoding"); encoding != testCase.encoding {
+				t.Fatalf("Invalid Content-Encoding, got %s, expected %s", encoding, testCase.encoding)
+			}
+
+			varyFieldNames := getVaryFieldNames(response.Header)
+			if count := varyFieldNames["accept-encoding"]; count > 1 {
+				t.Errorf("Accept-Encoding is listed %d times in Vary", count)
+			} else if vary := count == 1; vary != testCase.vary {
+				t.Errorf("Invalid Vary, got %v, expected %v", vary, testCase.vary)
+			}
+
+			for fieldName := range getVaryFieldNames(http.Header{"Vary": {testCase.varyHeader}}) {
+				if varyFieldNames[fieldName] == 0 {
+					t.Errorf("Missing %s in Vary", fieldName)
+				}
+			}
+
+			if etag := response.Header.Get("ETag"); etag != testCase.resultETag {
+				t.Errorf("Invalid ETag, got %s, expected %s", etag, testCase.resultETag)
+			}
+
+			if method == http.MethodHead {
+				if body, _ := ioutil.ReadAll(response.Body); len(body) > 0 {
+					t.Errorf("Unexpected body of %d bytes for a HEAD request", len(body))
+				}
+				return
+			}
+
+			var reader io.Reader = response.Body
+			switch testCase.encoding {
+			case "gzip":
+				if len(response.Header.Get("Content-Length")) > 0 {
+					t.Errorf("Unexpected Content-Length for compressed response")
+				}
+
+				gzipReader, err := gzip.NewReader(response.Body)
+				if err != nil {
+					t.Fatalf("Unexpected error \"%v\"", err)
+				}
+				reader = gzipReader
+			case "deflate":
+				zlibReader, err := zlib.NewReader(response.Body)
+				if err != nil {
+					t.Fatalf("Unexpected error \"%v\"", err)
+				}
+				reader = zlibReader
+			}
+
+			body, err := ioutil.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			}
+
+			if string(body) != testCase.body {
+				t.Errorf("Invalid body, got %d bytes, expected %d bytes", len(body), len(testCase.body))
+			}
+		})
+	}
+}
+
+func TestCompressHandlerWithOptions(t *testing.T) {
+	options := contenttype.CompressionOptions{
+		MinSize:            0,
+		Level:              gzip.BestSpeed,
+		ExcludedMediaTypes: []contenttype.MediaType{contenttype.NewMediaType("text/event-stream")},
+	}
+
+	testCases := []struct {
+		name        string
+		contentType string
+		encoding    string
+	}{
+		{name: "Compressed media type", contentType: "text/plain", encoding: "gzip"},
+		{name: "Excluded media type", contentType: "text/event-stream", encoding: ""},
+		{name: "Default excluded media type", contentType: "image/png", encoding: "gzip"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			request.Header.Set("Accept-Encoding", "gzip")
+
+			handler := contenttype.CompressHandlerWithOptions(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", testCase.contentType)
+				io.WriteString(w, "a")
+			}), options)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if encoding := recorder.Header().Get("Content-Encoding"); encoding != testCase.encoding {
+				t.Errorf("Invalid Content-Encoding, got %s, expected %s", encoding, testCase.encoding)
+			}
+		})
+	}
+}
+
+type informationalRecorder struct {
+	*httptest.ResponseRecorder
+	informationalStatusCodes []int
+}
+
+func (recorder *informationalRecorder) WriteHeader(statusCode int) {
+	if statusCode >= 100 && statusCode < http.StatusOK {
+		recorder.informationalStatusCodes = append(recorder.informationalStatusCodes, statusCode)
+		return
+	}
+
+	recorder.ResponseRecorder.WriteHeader(statusCode)
+}
+
+func TestCompressHandlerInformational(t *testing.T) {
+	body := strings.Repeat("Hello, world! ", 100)
+
+	request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	handler := contenttype.CompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", "</style.css>; rel=preload; as=style")
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, body)
+	}))
+
+	recorder := &informationalRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(recorder, request)
+
+	if len(recorder.informationalStatusCodes) != 1 || recorder.informationalStatusCodes[0] != http.StatusEarlyHints {
+		t.Errorf("Invalid informational status codes, got %v, expected [%d]", recorder.informationalStatusCodes, http.StatusEarlyHints)
+	}
+
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("Invalid status code, got %d, expected %d", recorder.Code, http.StatusCreated)
+	}
+
+	if encoding := recorder.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Errorf("Invalid Content-Encoding, got %s, expected gzip", encoding)
+	}
+}
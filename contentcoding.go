@@ -0,0 +1,99 @@
+package contenttype
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Content codings registered in the HTTP Content Coding Registry.
+const (
+	EncodingIdentity = "identity"
+	EncodingGzip     = "gzip"
+	EncodingDeflate  = "deflate"
+	EncodingCompress = "compress"
+	EncodingBrotli   = "br"
+	EncodingZstd     = "zstd"
+)
+
+// GetAcceptableEncoding chooses a content coding from available content codings according to the Accept-Encoding
+// header. If the request does not contain the Accept-Encoding header, the first available content coding is returned.
+// Returns the most suitable content coding or an error if no content coding can be selected.
+func GetAcceptableEncoding(request *http.Request, availableEncodings []string) (string, error) {
+	// RFC 7231, 5.3.4. Accept-Encoding
+	if len(availableEncodings) == 0 {
+		return "", ErrNoAvailableEncodingGiven
+	}
+
+	acceptEncodingHeader, found := getListHeader(request.Header, "Accept-Encoding")
+	if !found {
+		return availableEncodings[0], nil
+	}
+
+	return GetAcceptableEncodingFromHeader(acceptEncodingHeader, availableEncodings)
+}
+
+// GetAcceptableEncodingFromHeader chooses a content coding from available content codings according to the specified
+// Accept-Encoding header value. Content codings are compared case-insensitively, x-gzip and x-compress are treated
+// as gzip and compress, and "*" matches every content coding not listed explicitly.
+// The identity coding is acceptable with the lowest preference unless it is listed explicitly or excluded by
+// "identity;q=0" or "*;q=0".
+// Returns the most suitable content coding or an error if no content coding can be selected.
+func GetAcceptableEncodingFromHeader(headerValue string, availableEncodings []string) (string, error) {
+	// RFC 7231, 5.3.4. Accept-Encoding
+	acceptableEncodings, err := parseWeightedTokens(headerValue, ErrInvalidEncoding)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range acceptableEncodings {
+		acceptableEncodings[i].token = normalizeEncoding(acceptableEncodings[i].token)
+	}
+
+	resultIndex := -1
+	resultWeight := uint(0)
+	resultOrder := 0
+	for i, availableEncoding := range availableEncodings {
+		encoding := normalizeEncoding(strings.ToLower(availableEncoding))
+
+		weight, order, found := getEncodingWeight(acceptableEncodings, encoding)
+		if !found || weight == 0 {
+			continue
+		}
+
+		if resultIndex == -1 || weight > resultWeight || (weight == resultWeight && order < resultOrder) {
+			resultIndex = i
+			resultWeight = weight
+			resultOrder = order
+		}
+	}
+
+	if resultIndex == -1 {
+		return "", ErrNoAcceptableEncodingFound
+	}
+
+	return availableEncodings[resultIndex], nil
+}
+
+// getEncodingWeight returns the weight and the position of the content coding in the list, the identity coding is
+// implicitly acceptable with the lowest weight.
+func getEncodingWeight(acceptableEncodings []weightedToken, encoding string) (weight uint, order int, found bool) {
+	weight, order, found = getTokenWeight(acceptableEncodings, encoding)
+	if !found && encoding == EncodingIdentity {
+		// identity is implicitly acceptable, but only if nothing else is
+		return 1, len(acceptableEncodings), true
+	}
+
+	return weight, order, found
+}
+
+func normalizeEncoding(encoding string) string {
+	// RFC 7230, 4.2.1. Compress Coding and 4.2.3. Gzip Coding
+	switch encoding {
+	case "x-gzip":
+		return EncodingGzip
+	case "x-compress":
+		return EncodingCompress
+	default:
+		return encoding
+	}
+}
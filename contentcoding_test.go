@@ -0,0 +1,82 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestGetAcceptableEncoding(t *testing.T) {
+	testCases := []struct {
+		name               string
+		header             []string
+		availableEncodings []string
+		result             string
+	}{
+		{name: "No header", header: nil, availableEncodings: []string{"br", "gzip", "identity"}, result: "br"},
+		{name: "Empty header", header: []string{""}, availableEncodings: []string{"br", "gzip", "identity"}, result: "identity"},
+		{name: "Single encoding", header: []string{"gzip"}, availableEncodings: []string{"br", "gzip", "identity"}, result: "gzip"},
+		{name: "Browser header", header: []string{"gzip, deflate, br, zstd"}, availableEncodings: []string{"zstd", "br", "gzip"}, result: "gzip"},
+		{name: "Weights", header: []string{"gzip;q=0.5, br;q=1.0"}, availableEncodings: []string{"gzip", "br"}, result: "br"},
+		{name: "Upper-case encoding", header: []string{"GZIP"}, availableEncodings: []string{"gzip"}, result: "gzip"},
+		{name: "Legacy x-gzip", header: []string{"x-gzip"}, availableEncodings: []string{"br", "gzip"}, result: "gzip"},
+		{name: "Legacy x-gzip available", header: []string{"gzip"}, availableEncodings: []string{"x-gzip"}, result: "x-gzip"},
+		{name: "Implicit identity", header: []string{"br"}, availableEncodings: []string{"gzip", "identity"}, result: "identity"},
+		{name: "Implicit identity after weighted encoding", header: []string{"gzip;q=0.1"}, availableEncodings: []string{"identity", "gzip"}, result: "gzip"},
+		{name: "Wildcard", header: []string{"*"}, availableEncodings: []string{"zstd", "gzip"}, result: "zstd"},
+		{name: "Wildcard with exclusion", header: []string{"*, zstd;q=0"}, availableEncodings: []string{"zstd", "gzip"}, result: "gzip"},
+		{name: "Encoding in second field line", header: []string{"br", "gzip"}, availableEncodings: []string{"gzip"}, result: "gzip"},
+		{name: "Exclusion in second field line", header: []string{"*", "gzip;q=0"}, availableEncodings: []string{"gzip", "deflate"}, result: "deflate"},
+		{name: "Wildcard exclusion with explicit identity", header: []string{"*;q=0, identity"}, availableEncodings: []string{"gzip", "identity"}, result: "identity"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			for _, header := range testCase.header {
+				request.Header.Add("Accept-Encoding", header)
+			}
+
+			result, err := contenttype.GetAcceptableEncoding(request, testCase.availableEncodings)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if result != testCase.result {
+				t.Errorf("Invalid encoding, got %s, expected %s for %s", result, testCase.result, testCase.header)
+			}
+		})
+	}
+}
+
+func TestGetAcceptableEncodingErrors(t *testing.T) {
+	testCases := []struct {
+		name               string
+		header             string
+		availableEncodings []string
+		err                error
+	}{
+		{name: "No available encoding", header: "gzip", availableEncodings: nil, err: contenttype.ErrNoAvailableEncodingGiven},
+		{name: "No acceptable encoding", header: "br", availableEncodings: []string{"gzip"}, err: contenttype.ErrNoAcceptableEncodingFound},
+		{name: "Excluded identity", header: "br, identity;q=0", availableEncodings: []string{"identity"}, err: contenttype.ErrNoAcceptableEncodingFound},
+		{name: "Excluded wildcard", header: "*;q=0", availableEncodings: []string{"gzip", "identity"}, err: contenttype.ErrNoAcceptableEncodingFound},
+		{name: "Invalid encoding", header: "gzip, @", availableEncodings: []string{"gzip"}, err: contenttype.ErrInvalidEncoding},
+		{name: "Invalid weight", header: "gzip;q=1.5", availableEncodings: []string{"gzip"}, err: contenttype.ErrInvalidWeight},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			request.Header.Set("Accept-Encoding", testCase.header)
+
+			_, err := contenttype.GetAcceptableEncoding(request, testCase.availableEncodings)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+		})
+	}
+}
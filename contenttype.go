@@ -1,20 +1,50 @@
-// Package contenttype implements HTTP Content-Type, Accept and Accept-Language header parsers.
+// Package contenttype implements HTTP Content-Type, Accept, Accept-Charset, Accept-Encoding and Accept-Language header parsers.
 package contenttype
 
 import (
 	"net/http"
-	"reflect"
+	"sort"
 	"strings"
 )
 
 // Parameters represents media type parameters as a key-value map.
 type Parameters = map[string]string
 
+// Tree represents the registration tree of a media type.
+type Tree int
+
+const (
+	// TreeStandard is the tree of the subtypes without a facet (e.g. application/json).
+	TreeStandard Tree = iota
+	// TreeVendor is the tree of the subtypes with the vnd. facet (e.g. application/vnd.api+json).
+	TreeVendor
+	// TreePersonal is the tree of the subtypes with the prs. facet (e.g. application/prs.example).
+	TreePersonal
+	// TreeUnregistered is the tree of the subtypes with the x. or x- facet (e.g. application/x-www-form-urlencoded).
+	TreeUnregistered
+)
+
+// Converts the Tree to string.
+func (tree Tree) String() string {
+	switch tree {
+	case TreeStandard:
+		return "standard"
+	case TreeVendor:
+		return "vendor"
+	case TreePersonal:
+		return "personal"
+	case TreeUnregistered:
+		return "unregistered"
+	default:
+		return "unknown"
+	}
+}
+
 // MediaType holds the type, subtype and parameters of a media type.
 type MediaType struct {
 	Type       string
 	Subtype    string
-	Parameters Parameters
+	Parameters Parameters // nil if the media type was parsed without parameters
 }
 
 // NewMediaType parses the string and returns an instance of MediaType struct.
@@ -28,6 +58,8 @@ func NewMediaType(s string) MediaType {
 }
 
 // Converts the MediaType to string.
+// The parameters that can't be represented in a header field (names that are not tokens or values containing
+// control characters) are left out, so that the result can always be parsed back with ParseMediaType.
 func (mediaType MediaType) String() string {
 	var stringBuilder strings.Builder
 
@@ -36,17 +68,64 @@ func (mediaType MediaType) String() string {
 		stringBuilder.WriteByte('/')
 		stringBuilder.WriteString(mediaType.Subtype)
 
-		for key, value := range mediaType.Parameters {
+		// sort the parameters to make the output deterministic
+		keys := make([]string, 0, len(mediaType.Parameters))
+		for key := range mediaType.Parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value := mediaType.Parameters[key]
+			if !isToken(key) || !isQuotableValue(value) {
+				continue
+			}
+
 			stringBuilder.WriteByte(';')
 			stringBuilder.WriteString(key)
 			stringBuilder.WriteByte('=')
-			stringBuilder.WriteString(value)
+			writeParameterValue(&stringBuilder, value)
 		}
 	}
 
 	return stringBuilder.String()
 }
 
+func isToken(s string) bool {
+	_, remaining, consumed := consumeToken(s)
+	return consumed && len(remaining) == 0
+}
+
+// isQuotableValue checks whether the value can be written as a quoted string.
+func isQuotableValue(value string) bool {
+	// RFC 7230, 3.2.6. Field Value Components
+	for i := 0; i < len(value); i++ {
+		if !isQuotedPairChar(value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeParameterValue(stringBuilder *strings.Builder, value string) {
+	// RFC 7231, 3.1.1.1. Media Type
+	if isToken(value) {
+		stringBuilder.WriteString(value)
+		return
+	}
+
+	// RFC 7230, 3.2.6. Field Value Components
+	stringBuilder.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' || value[i] == '\\' {
+			stringBuilder.WriteByte('\\')
+		}
+		stringBuilder.WriteByte(value[i])
+	}
+	stringBuilder.WriteByte('"')
+}
+
 // MIME returns the MIME type without any of the parameters
 func (mediaType MediaType) MIME() string {
 	var stringBuilder strings.Builder
@@ -61,9 +140,19 @@ func (mediaType MediaType) MIME() string {
 }
 
 // Equal checks whether the provided MIME media type matches this one
-// including all parameters
+// including all parameters, nil and empty Parameters are equal
 func (mediaType MediaType) Equal(mt MediaType) bool {
-	return reflect.DeepEqual(mediaType, mt)
+	if mediaType.Type != mt.Type || mediaType.Subtype != mt.Subtype || len(mediaType.Parameters) != len(mt.Parameters) {
+		return false
+	}
+
+	for key, value := range mediaType.Parameters {
+		if otherValue, found := mt.Parameters[key]; !found || otherValue != value {
+			return false
+		}
+	}
+
+	return true
 }
 
 // EqualsMIME checks whether the base MIME types match
@@ -71,10 +160,11 @@ func (mediaType MediaType) EqualsMIME(mt MediaType) bool {
 	return (mediaType.Type == mt.Type) && (mediaType.Subtype == mt.Subtype)
 }
 
-// Matches checks whether the MIME media types match handling wildcards in either
+// Matches checks whether the MIME media types match handling wildcards in either,
+// including structured syntax suffix wildcards (e.g. application/*+json)
 func (mediaType MediaType) Matches(mt MediaType) bool {
 	t := mediaType.Type == mt.Type || (mediaType.Type == "*") || (mt.Type == "*")
-	st := mediaType.Subtype == mt.Subtype || mediaType.Subtype == "*" || mt.Subtype == "*"
+	st := matchesSubtype(mediaType.Subtype, mt, false) || matchesSubtype(mt.Subtype, mediaType, false)
 	return t && st
 }
 
@@ -89,6 +179,52 @@ func (mediaType MediaType) MatchesAny(mts ...MediaType) bool {
 	return false
 }
 
+// Suffix returns the structured syntax suffix of the subtype (e.g. json for application/vnd.api+json)
+// or an empty string if the subtype has none
+func (mediaType MediaType) Suffix() string {
+	// RFC 6838, 4.2.8. Structured Syntax Name Suffixes
+	if index := strings.LastIndexByte(mediaType.Subtype, '+'); index != -1 {
+		return mediaType.Subtype[index+1:]
+	}
+
+	return ""
+}
+
+// Tree returns the registration tree of the subtype determined by its facet
+func (mediaType MediaType) Tree() Tree {
+	// RFC 6838, 3. Registration Trees and Subtype Names
+	subtype := strings.ToLower(mediaType.Subtype)
+	switch {
+	case strings.HasPrefix(subtype, "vnd."):
+		return TreeVendor
+	case strings.HasPrefix(subtype, "prs."):
+		return TreePersonal
+	case strings.HasPrefix(subtype, "x.") || strings.HasPrefix(subtype, "x-"):
+		return TreeUnregistered
+	default:
+		return TreeStandard
+	}
+}
+
+// SubtypeName returns the subtype without the tree facet and the structured syntax suffix
+// (e.g. api for application/vnd.api+json)
+func (mediaType MediaType) SubtypeName() string {
+	// RFC 6838, 3. Registration Trees and Subtype Names
+	name := mediaType.Subtype
+	switch mediaType.Tree() {
+	case TreeVendor, TreePersonal:
+		name = name[4:]
+	case TreeUnregistered:
+		name = name[2:]
+	}
+
+	if suffix := mediaType.Suffix(); len(suffix) > 0 || strings.HasSuffix(name, "+") {
+		name = name[:len(name)-len(suffix)-1]
+	}
+
+	return name
+}
+
 // IsWildcard returns true if either the Type or Subtype are the wildcard character '*'
 func (mediaType MediaType) IsWildcard() bool {
 	return mediaType.Type == `*` || mediaType.Subtype == `*`
@@ -106,18 +242,70 @@ func GetMediaType(request *http.Request) (MediaType, error) {
 	return ParseMediaType(contentTypeHeaders[0])
 }
 
+// GetMediaTypeStrict gets the content of Content-Type header, parses it, and returns the parsed MediaType like
+// GetMediaType does, but returns ErrAmbiguousMediaType if the request contains several Content-Type headers with
+// different media types or a Content-Type header with a comma-separated list of media types, as intermediaries may
+// disagree on which of them applies.
+// If the request does not contain the Content-Type header, an empty MediaType is returned.
+func GetMediaTypeStrict(request *http.Request) (MediaType, error) {
+	// RFC 7230, 3.2.2. Field Order
+	contentTypeHeaders := request.Header.Values("Content-Type")
+	if len(contentTypeHeaders) == 0 {
+		return MediaType{}, nil
+	}
+
+	var result MediaType
+	for i, contentTypeHeader := range contentTypeHeaders {
+		if containsListSeparator(contentTypeHeader) {
+			return MediaType{}, ErrAmbiguousMediaType
+		}
+
+		mediaType, err := ParseMediaType(contentTypeHeader)
+		if err != nil {
+			return MediaType{}, err
+		}
+
+		if i == 0 {
+			result = mediaType
+		} else if !mediaType.Equal(result) {
+			return MediaType{}, ErrAmbiguousMediaType
+		}
+	}
+
+	return result, nil
+}
+
+// ParseOptions alters how media types are parsed.
+type ParseOptions struct {
+	// LowercaseParameterValues converts all parameter values to lower case, like the previous versions of this
+	// package did. By default only the values of case-insensitive parameters (e.g. charset) are converted.
+	LowercaseParameterValues bool
+}
+
 // ParseMediaType parses the given string as a MIME media type (with optional parameters) and returns it as a MediaType.
+// The Parameters of the result are nil if the media type has none.
 // If the string cannot be parsed an appropriate error is returned.
 func ParseMediaType(s string) (MediaType, error) {
+	return ParseMediaTypeWithOptions(s, ParseOptions{})
+}
+
+// ParseMediaTypeWithOptions parses the given string as a MIME media type (with optional parameters) using the given
+// options and returns it as a MediaType.
+// If the string cannot be parsed an appropriate error is returned.
+func ParseMediaTypeWithOptions(s string, options ParseOptions) (MediaType, error) {
 	// RFC 7231, 3.1.1.1. Media Type
-	mediaType := MediaType{
-		Parameters: Parameters{},
-	}
+	mediaType := MediaType{}
 	var consumed bool
 	if mediaType.Type, mediaType.Subtype, s, consumed = consumeType(skipWhitespaces(s)); !consumed {
 		return MediaType{}, ErrInvalidMediaType
 	}
 
+	// only the media types are validated against the naming rules, an odd media range of the Accept header must not
+	// make the whole header invalid
+	if !isRestrictedMediaTypeName(mediaType.Type, mediaType.Subtype) {
+		return MediaType{}, ErrInvalidMediaType
+	}
+
 	for len(s) > 0 {
 		var skipped bool
 		s, skipped = skipCharacter(s, ';')
@@ -133,138 +321,311 @@ func ParseMediaType(s string) (MediaType, error) {
 			return MediaType{}, ErrInvalidParameter
 		}
 
+		if options.LowercaseParameterValues {
+			value = strings.ToLower(value)
+		}
+
+		if mediaType.Parameters == nil {
+			mediaType.Parameters = Parameters{}
+		}
 		mediaType.Parameters[key] = value
 	}
 
 	return mediaType, nil
 }
 
+// NegotiationOptions alters how an acceptable media type is chosen.
+type NegotiationOptions struct {
+	// MatchSuffix lets a media range match available types whose structured syntax suffix equals its subtype
+	// (e.g. application/json matches application/vnd.api+json).
+	// Suffix wildcards (e.g. application/*+json) are always matched.
+	MatchSuffix bool
+}
+
 // GetAcceptableMediaType chooses a media type from available media types according to the Accept header.
 // Returns the most suitable media type or an error if no type can be selected.
+// The returned accept extension parameters are nil if the matching media range has none.
 func GetAcceptableMediaType(request *http.Request, availableMediaTypes []MediaType) (MediaType, Parameters, error) {
+	return GetAcceptableMediaTypeWithOptions(request, availableMediaTypes, NegotiationOptions{})
+}
+
+// GetAcceptableMediaTypeWithOptions chooses a media type from available media types according to the Accept header
+// using the given options.
+// Returns the most suitable media type or an error if no type can be selected.
+func GetAcceptableMediaTypeWithOptions(request *http.Request, availableMediaTypes []MediaType, options NegotiationOptions) (MediaType, Parameters, error) {
 	// RFC 7231, 5.3.2. Accept
 	if len(availableMediaTypes) == 0 {
-		return MediaType{}, Parameters{}, ErrNoAvailableTypeGiven
+		return MediaType{}, nil, ErrNoAvailableTypeGiven
 	}
 
-	acceptHeaders := request.Header.Values("Accept")
-	if len(acceptHeaders) == 0 {
-		return availableMediaTypes[0], Parameters{}, nil
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+	if !found {
+		return availableMediaTypes[0], nil, nil
 	}
 
-	return GetAcceptableMediaTypeFromHeader(acceptHeaders[0], availableMediaTypes)
+	return GetAcceptableMediaTypeFromHeaderWithOptions(acceptHeader, availableMediaTypes, options)
 }
 
 // GetAcceptableMediaTypeFromHeader chooses a media type from available media types according to the specified Accept header value.
 // Returns the most suitable media type or an error if no type can be selected.
 func GetAcceptableMediaTypeFromHeader(headerValue string, availableMediaTypes []MediaType) (MediaType, Parameters, error) {
-	s := headerValue
-
-	weights := make([]struct {
-		mediaType           MediaType
-		extensionParameters Parameters
-		weight              uint
-		order               uint
-	}, len(availableMediaTypes))
-
-	for mediaTypeCount := uint(0); len(s) > 0; mediaTypeCount++ {
-		if mediaTypeCount > 0 {
-			// every media type after the first one must start with a comma
-			var skipped bool
-			s, skipped = skipCharacter(s, ',')
-			if !skipped {
-				break
+	return GetAcceptableMediaTypeFromHeaderWithOptions(headerValue, availableMediaTypes, NegotiationOptions{})
+}
+
+// GetAcceptableMediaTypeFromHeaderWithOptions chooses a media type from available media types according to the
+// specified Accept header value using the given options.
+// Returns the most suitable media type or an error if no type can be selected.
+func GetAcceptableMediaTypeFromHeaderWithOptions(headerValue string, availableMediaTypes []MediaType, options NegotiationOptions) (MediaType, Parameters, error) {
+	var buffer mediaTypeWeightBuffer
+	weights := buffer.get(len(availableMediaTypes))
+
+	if err := getMediaTypeWeights(headerValue, availableMediaTypes, options.MatchSuffix, weights); err != nil {
+		return MediaType{}, nil, err
+	}
+
+	resultIndex := -1
+	for i, weight := range weights {
+		if resultIndex != -1 {
+			if weight.weight > weights[resultIndex].weight ||
+				(weight.weight == weights[resultIndex].weight && weight.order < weights[resultIndex].order) {
+				resultIndex = i
 			}
+		} else if weight.weight > 0 {
+			resultIndex = i
 		}
+	}
+
+	if resultIndex == -1 {
+		return MediaType{}, nil, ErrNoAcceptableTypeFound
+	}
+
+	return availableMediaTypes[resultIndex], parseParameters(weights[resultIndex].extensionParameters), nil
+}
 
-		acceptableMediaType := MediaType{
-			Parameters: Parameters{},
+// getListHeader combines all the field lines of a list-based header into a single comma-separated value.
+func getListHeader(header http.Header, key string) (value string, found bool) {
+	// RFC 7230, 3.2.2. Field Order
+	values := header.Values(key)
+	switch len(values) {
+	case 0:
+		return "", false
+	case 1:
+		return values[0], true
+	}
+
+	var stringBuilder strings.Builder
+	for _, value := range values {
+		// RFC 7230, 7. ABNF List Extension: #rule
+		if len(skipWhitespaces(value)) == 0 {
+			continue
 		}
-		var consumed bool
-		if acceptableMediaType.Type, acceptableMediaType.Subtype, s, consumed = consumeType(skipWhitespaces(s)); !consumed {
-			return MediaType{}, Parameters{}, ErrInvalidMediaType
+
+		if stringBuilder.Len() > 0 {
+			stringBuilder.WriteString(", ")
 		}
+		stringBuilder.WriteString(value)
+	}
 
-		weight := uint(1000) // 1.000
+	return stringBuilder.String(), true
+}
 
-		// media type parameters
-		for len(s) > 0 {
-			var skipped bool
-			s, skipped = skipCharacter(s, ';')
+// mediaTypeWeight is the most specific media range of the Accept header matching an available media type.
+// The accept extension parameters are kept unparsed until they are needed.
+type mediaTypeWeight struct {
+	mediaType           MediaType
+	extensionParameters string
+	weight              uint
+	order               uint
+}
 
-			if !skipped {
-				break
-			}
+// mediaTypeWeightBuffer avoids allocating the weights for short lists of available media types.
+type mediaTypeWeightBuffer [16]mediaTypeWeight
 
-			var key, value string
-			if key, value, s, consumed = consumeParameter(s); !consumed {
-				return MediaType{}, Parameters{}, ErrInvalidParameter
-			}
+func (buffer *mediaTypeWeightBuffer) get(count int) []mediaTypeWeight {
+	if count <= len(buffer) {
+		return buffer[:count]
+	}
 
-			if key == "q" {
-				if weight, consumed = getWeight(value); !consumed {
-					return MediaType{}, Parameters{}, ErrInvalidWeight
-				}
-				break // "q" parameter separates media type parameters from Accept extension parameters
+	return make([]mediaTypeWeight, count)
+}
+
+// getMediaTypeWeights fills the weights (one for every available media type) according to the Accept header value.
+func getMediaTypeWeights(headerValue string, availableMediaTypes []MediaType, matchSuffix bool, weights []mediaTypeWeight) error {
+	return forEachMediaRange(headerValue, func(mediaRange MediaType, weight uint, extensionParameters string, order uint) {
+		for i, availableMediaType := range availableMediaTypes {
+			if compareMediaTypes(mediaRange, availableMediaType, matchSuffix) &&
+				getPrecedence(mediaRange, weights[i].mediaType, availableMediaType) {
+				weights[i].mediaType = mediaRange
+				weights[i].extensionParameters = extensionParameters
+				weights[i].weight = weight
+				weights[i].order = order
 			}
+		}
+	})
+}
 
-			acceptableMediaType.Parameters[key] = value
+// forEachMediaRange parses the Accept header value and calls the handler for every media range in it.
+func forEachMediaRange(s string, handler func(mediaRange MediaType, weight uint, extensionParameters string, order uint)) error {
+	// RFC 7231, 5.3.2. Accept
+	return forEachListElement(s, ErrInvalidMediaRange, func(s string, order uint) (string, error) {
+		mediaRange, weight, extensionParameters, remaining, err := consumeMediaRange(s)
+		if err != nil {
+			return s, err
 		}
 
-		extensionParameters := Parameters{}
-		for len(s) > 0 {
-			var skipped bool
-			s, skipped = skipCharacter(s, ';')
+		handler(mediaRange, weight, extensionParameters, order)
+		return remaining, nil
+	})
+}
 
+// forEachListElement calls consume for every element of a comma-separated header value (e.g. Accept or
+// Accept-Charset). consume parses the element at the start of the string and returns the rest of the string.
+// errInvalidList is returned if the header value contains anything else than the elements separated by commas.
+func forEachListElement(s string, errInvalidList error, consume func(s string, order uint) (remaining string, err error)) error {
+	for order := uint(0); len(s) > 0; order++ {
+		if order > 0 {
+			// every element after the first one must start with a comma
+			var skipped bool
+			s, skipped = skipCharacter(s, ',')
 			if !skipped {
 				break
 			}
+		}
 
-			var key, value, remaining string
-			if key, value, remaining, consumed = consumeParameter(s); !consumed {
-				return MediaType{}, Parameters{}, ErrInvalidParameter
-			}
+		var err error
+		if s, err = consume(s, order); err != nil {
+			return err
+		}
+	}
+
+	// there must not be anything left after parsing the header
+	if len(s) > 0 {
+		return errInvalidList
+	}
 
-			s = remaining
+	return nil
+}
 
-			extensionParameters[key] = value
+// consumeMediaRange consumes a media range of the Accept header. To avoid allocations the Parameters of the media
+// range are nil if it has none, and the accept extension parameters are returned unparsed (see parseParameters).
+func consumeMediaRange(s string) (mediaRange MediaType, weight uint, extensionParameters, remaining string, err error) {
+	// RFC 7231, 5.3.2. Accept
+	var consumed bool
+	if mediaRange.Type, mediaRange.Subtype, s, consumed = consumeType(skipWhitespaces(s)); !consumed {
+		return MediaType{}, 0, "", s, ErrInvalidMediaType
+	}
+
+	weight = 1000 // 1.000
+
+	// media type parameters
+	for len(s) > 0 {
+		var skipped bool
+		s, skipped = skipCharacter(s, ';')
+
+		if !skipped {
+			break
 		}
 
-		for i, availableMediaType := range availableMediaTypes {
-			if compareMediaTypes(acceptableMediaType, availableMediaType) &&
-				getPrecedence(acceptableMediaType, weights[i].mediaType) {
-				weights[i].mediaType = acceptableMediaType
-				weights[i].extensionParameters = extensionParameters
-				weights[i].weight = weight
-				weights[i].order = mediaTypeCount
+		var key, value string
+		if key, value, s, consumed = consumeParameter(s); !consumed {
+			return MediaType{}, 0, "", s, ErrInvalidParameter
+		}
+
+		if key == "q" {
+			if weight, consumed = getWeight(value); !consumed {
+				return MediaType{}, 0, "", s, ErrInvalidWeight
 			}
+			break // "q" parameter separates media type parameters from Accept extension parameters
 		}
 
-		s = skipWhitespaces(s)
+		if mediaRange.Parameters == nil {
+			mediaRange.Parameters = Parameters{}
+		}
+		mediaRange.Parameters[key] = value
 	}
 
-	// there must not be anything left after parsing the header
-	if len(s) > 0 {
-		return MediaType{}, Parameters{}, ErrInvalidMediaRange
+	extensionParametersStart := s
+	for len(s) > 0 {
+		var skipped bool
+		s, skipped = skipCharacter(s, ';')
+
+		if !skipped {
+			break
+		}
+
+		if _, _, s, consumed = consumeParameter(s); !consumed {
+			return MediaType{}, 0, "", s, ErrInvalidParameter
+		}
 	}
+	extensionParameters = extensionParametersStart[:len(extensionParametersStart)-len(s)]
 
-	resultIndex := -1
-	for i, weight := range weights {
-		if resultIndex != -1 {
-			if weight.weight > weights[resultIndex].weight ||
-				(weight.weight == weights[resultIndex].weight && weight.order < weights[resultIndex].order) {
-				resultIndex = i
-			}
-		} else if weight.weight > 0 {
-			resultIndex = i
+	return mediaRange, weight, extensionParameters, skipWhitespaces(s), nil
+}
+
+// parseParameters parses a list of parameters already validated by consumeMediaRange (e.g. "; a=b; c=d"),
+// the result is nil if there are none.
+func parseParameters(s string) Parameters {
+	var parameters Parameters
+
+	for len(s) > 0 {
+		var skipped, consumed bool
+		if s, skipped = skipCharacter(s, ';'); !skipped {
+			break
 		}
+
+		var key, value string
+		if key, value, s, consumed = consumeParameter(s); !consumed {
+			break
+		}
+
+		if parameters == nil {
+			parameters = Parameters{}
+		}
+		parameters[key] = value
 	}
 
-	if resultIndex == -1 {
-		return MediaType{}, Parameters{}, ErrNoAcceptableTypeFound
+	return parameters
+}
+
+// weightedToken is an element of a weighted list of tokens (e.g. Accept-Charset or Accept-Encoding).
+type weightedToken struct {
+	token  string
+	weight uint
+}
+
+func parseWeightedTokens(s string, errInvalidToken error) ([]weightedToken, error) {
+	// RFC 7231, 5.3.1. Quality Values
+	var result []weightedToken
+
+	err := forEachListElement(s, errInvalidToken, func(s string, order uint) (string, error) {
+		token, remaining, consumed := consumeToken(skipWhitespaces(s))
+		if !consumed {
+			return s, errInvalidToken
+		}
+		s = skipWhitespaces(remaining)
+
+		weight := uint(1000) // 1.000
+
+		var skipped bool
+		if s, skipped = skipCharacter(s, ';'); skipped {
+			var key, value string
+			if key, value, s, consumed = consumeParameter(s); !consumed || key != "q" {
+				return s, ErrInvalidParameter
+			}
+
+			if weight, consumed = getWeight(value); !consumed {
+				return s, ErrInvalidWeight
+			}
+		}
+
+		result = append(result, weightedToken{token: strings.ToLower(token), weight: weight})
+		return s, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return availableMediaTypes[resultIndex], weights[resultIndex].extensionParameters, nil
+	return result, nil
 }
 
 func isWhitespaceChar(c byte) bool {
@@ -280,6 +641,28 @@ func isTokenChar(c byte) bool {
 		isAlphaChar(c)
 }
 
+func isRestrictedNameChar(c byte) bool {
+	// RFC 6838, 4.2. Naming Requirements
+	return c == '!' || c == '#' || c == '$' || c == '&' || c == '-' || c == '^' || c == '_' || c == '.' || c == '+' ||
+		isDigitChar(c) ||
+		isAlphaChar(c)
+}
+
+func isRestrictedName(s string) bool {
+	// RFC 6838, 4.2. Naming Requirements
+	if len(s) == 0 || len(s) > 127 || (!isAlphaChar(s[0]) && !isDigitChar(s[0])) {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !isRestrictedNameChar(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func isObsoleteTextChar(c byte) bool {
 	// RFC 7230, 3.2.6. Field Value Components
 	return c >= 0x80 // c is always less than or equal to 0xFF
@@ -316,18 +699,27 @@ func consumeToken(s string) (token, remaining string, consumed bool) {
 	// RFC 7230, 3.2.6. Field Value Components
 	for i := 0; i < len(s); i++ {
 		if !isTokenChar(s[i]) {
-			return strings.ToLower(s[:i]), s[i:], i > 0
+			return s[:i], s[i:], i > 0
 		}
 	}
 
-	return strings.ToLower(s), "", len(s) > 0
+	return s, "", len(s) > 0
 }
 
 func consumeQuotedString(s string) (token, remaining string, consumed bool) {
 	// RFC 7230, 3.2.6. Field Value Components
+	index := 0
+	for ; index < len(s) && isQuotedTextChar(s[index]); index++ {
+	}
+
+	if index == len(s) || s[index] != '\\' {
+		// no quoted pairs, the token is a substring of the input
+		return s[:index], s[index:], true
+	}
+
 	var stringBuilder strings.Builder
+	stringBuilder.WriteString(s[:index])
 
-	index := 0
 	for ; index < len(s); index++ {
 		if s[index] == '\\' {
 			index++
@@ -342,7 +734,7 @@ func consumeQuotedString(s string) (token, remaining string, consumed bool) {
 		}
 	}
 
-	return strings.ToLower(stringBuilder.String()), s[index:], true
+	return stringBuilder.String(), s[index:], true
 }
 
 func consumeType(s string) (tag, subtag, remaining string, consumed bool) {
@@ -366,7 +758,15 @@ func consumeType(s string) (tag, subtag, remaining string, consumed bool) {
 		return "", "", s, false
 	}
 
-	return t, st, skipWhitespaces(remaining), true
+	return strings.ToLower(t), strings.ToLower(st), skipWhitespaces(remaining), true
+}
+
+// isRestrictedMediaTypeName checks whether the type and subtype are restricted names, the * and *+suffix wildcards
+// are allowed as well.
+func isRestrictedMediaTypeName(t, st string) bool {
+	// RFC 6838, 4.2. Naming Requirements
+	return (t == "*" || isRestrictedName(t)) &&
+		(st == "*" || isRestrictedName(st) || (strings.HasPrefix(st, "*+") && isRestrictedName(st[2:])))
 }
 
 func consumeParameter(s string) (key, value, remaining string, consumed bool) {
@@ -394,9 +794,37 @@ func consumeParameter(s string) (key, value, remaining string, consumed bool) {
 		}
 	}
 
+	key = strings.ToLower(key)
+	if isCaseInsensitiveParameter(key) {
+		value = strings.ToLower(value)
+	}
+
 	return key, value, skipWhitespaces(remaining), true
 }
 
+// containsListSeparator checks whether the string contains a comma outside of quoted strings.
+func containsListSeparator(s string) bool {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++ // skip the escaped character
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == ',':
+			return true
+		}
+	}
+
+	return false
+}
+
+func isCaseInsensitiveParameter(key string) bool {
+	// RFC 7231, 3.1.1.1. Media Type
+	// parameter values are case-sensitive unless the parameter is defined otherwise
+	return key == "charset"
+}
+
 func getWeight(s string) (weight uint, consumed bool) {
 	// RFC 7231, 5.3.1. Quality Values
 	result := uint(0)
@@ -436,10 +864,10 @@ func getWeight(s string) (weight uint, consumed bool) {
 	return result, true
 }
 
-func compareMediaTypes(checkMediaType, mediaType MediaType) bool {
+func compareMediaTypes(checkMediaType, mediaType MediaType, matchSuffix bool) bool {
 	// RFC 7231, 5.3.2. Accept
 	if (checkMediaType.Type == "*" || checkMediaType.Type == mediaType.Type) &&
-		(checkMediaType.Subtype == "*" || checkMediaType.Subtype == mediaType.Subtype) {
+		matchesSubtype(checkMediaType.Subtype, mediaType, matchSuffix) {
 
 		for checkKey, checkValue := range checkMediaType.Parameters {
 			if value, found := mediaType.Parameters[checkKey]; !found || value != checkValue {
@@ -453,14 +881,43 @@ func compareMediaTypes(checkMediaType, mediaType MediaType) bool {
 	return false
 }
 
-func getPrecedence(checkMediaType, mediaType MediaType) bool {
+func matchesSubtype(subtype string, mediaType MediaType, matchSuffix bool) bool {
+	// RFC 6839, 2. When to Use a +suffix
+	if subtype == "*" || subtype == mediaType.Subtype {
+		return true
+	}
+
+	if strings.HasPrefix(subtype, "*+") {
+		return len(subtype) > 2 && subtype[2:] == mediaType.Suffix()
+	}
+
+	return matchSuffix && subtype == mediaType.Suffix()
+}
+
+// getRangeSpecificity returns how specific the media range matching the media type is, from the least specific */*
+// through type/*, type/*+suffix and the structured syntax suffix of the media type to its subtype itself.
+func getRangeSpecificity(mediaRange, mediaType MediaType) int {
+	switch {
+	case mediaRange.Type == "*":
+		return 0
+	case mediaRange.Subtype == "*":
+		return 1
+	case strings.HasPrefix(mediaRange.Subtype, "*+"):
+		return 2
+	case mediaRange.Subtype != mediaType.Subtype: // matched the suffix
+		return 3
+	default:
+		return 4
+	}
+}
+
+func getPrecedence(checkMediaType, mediaType, availableMediaType MediaType) bool {
 	// RFC 7231, 5.3.2. Accept
 	if len(mediaType.Type) == 0 || len(mediaType.Subtype) == 0 { // not set
 		return true
 	}
 
-	if (mediaType.Type == "*" && checkMediaType.Type != "*") ||
-		(mediaType.Subtype == "*" && checkMediaType.Subtype != "*") ||
+	if (getRangeSpecificity(mediaType, availableMediaType) < getRangeSpecificity(checkMediaType, availableMediaType)) ||
 		(len(mediaType.Parameters) < len(checkMediaType.Parameters)) {
 		return true
 	}
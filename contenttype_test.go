@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/elnormous/contenttype"
@@ -30,7 +31,7 @@ func TestNewMediaType(t *testing.T) {
 		result contenttype.MediaType
 	}{
 		{name: "Empty string", value: "", result: contenttype.MediaType{}},
-		{name: "Type and subtype", value: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
+		{name: "Type and subtype", value: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
 		{name: "Type, subtype, parameter", value: "a/b;c=d", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d"}}},
 		{name: "Subtype only", value: "/b", result: contenttype.MediaType{}},
 		{name: "Type only", value: "a/", result: contenttype.MediaType{}},
@@ -56,8 +57,8 @@ func TestParseMediaType(t *testing.T) {
 		value  string
 		result contenttype.MediaType
 	}{
-		{name: "Type and subtype", value: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
-		{name: "Type and subtype with whitespaces", value: "application/json   ", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
+		{name: "Type and subtype", value: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Type and subtype with whitespaces", value: "application/json   ", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
 		{name: "Type, subtype, parameter", value: "a/b;c=d", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d"}}},
 	}
 
@@ -75,6 +76,29 @@ func TestParseMediaType(t *testing.T) {
 	}
 }
 
+func TestParseMediaTypeWithOptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		options contenttype.ParseOptions
+		result  contenttype.MediaType
+	}{
+		{name: "Preserved value", value: "a/b;c=D;charset=UTF-8", options: contenttype.ParseOptions{}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "D", "charset": "utf-8"}}},
+		{name: "Lower-case values", value: "a/b;c=D;e=\"F\"", options: contenttype.ParseOptions{LowercaseParameterValues: true}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d", "e": "f"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := contenttype.ParseMediaTypeWithOptions(testCase.value, testCase.options)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.value)
+			} else if !reflect.DeepEqual(result, testCase.result) {
+				t.Errorf("Invalid media type, got %v, expected %v for %s", result, testCase.result, testCase.value)
+			}
+		})
+	}
+}
+
 func TestParseMediaTypeErrors(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -110,6 +134,14 @@ func TestMediaTypeString(t *testing.T) {
 		{name: "Empty media type", value: contenttype.MediaType{}, result: ""},
 		{name: "Type and subtype", value: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, result: "application/json"},
 		{name: "Type, subtype, parameter", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d"}}, result: "a/b;c=d"},
+		{name: "Sorted parameters", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"e": "f", "c": "d", "g": "h"}}, result: "a/b;c=d;e=f;g=h"},
+		{name: "Empty value", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": ""}}, result: "a/b;c=\"\""},
+		{name: "Value with space", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d e"}}, result: "a/b;c=\"d e\""},
+		{name: "Value with separators", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "https://a/b;c"}}, result: "a/b;c=\"https://a/b;c\""},
+		{name: "Value with quote and backslash", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\"e\\f"}}, result: "a/b;c=\"d\\\"e\\\\f\""},
+		{name: "Value with tab", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\te"}}, result: "a/b;c=\"d\te\""},
+		{name: "Value with control character", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\ne", "f": "g"}}, result: "a/b;f=g"},
+		{name: "Key that is not a token", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"d e": "f", "g": "h"}}, result: "a/b;g=h"},
 	}
 
 	for _, testCase := range testCases {
@@ -123,6 +155,51 @@ func TestMediaTypeString(t *testing.T) {
 	}
 }
 
+func TestMediaTypeStringRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value contenttype.MediaType
+	}{
+		{name: "Type and subtype", value: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Multiple parameters", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "D", "e": "f", "charset": "utf-8"}}},
+		{name: "Quoted values", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "", "d": "e f", "g": "\"h\\", "i": "j/k;l=m,n"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := contenttype.ParseMediaType(testCase.value.String())
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.value)
+			} else if !reflect.DeepEqual(result, testCase.value) {
+				t.Errorf("Invalid media type, got %v, expected %v", result, testCase.value)
+			}
+		})
+	}
+}
+
+func TestMediaTypeStringRoundTripInvalidParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		value  contenttype.MediaType
+		result contenttype.MediaType
+	}{
+		{name: "Control characters", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\r\ne", "f": "\x00", "g": "\x7f", "h": "i"}}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"h": "i"}}},
+		{name: "Keys that are not tokens", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"d e": "f", "g;h": "i", "": "j", "k": "l"}}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"k": "l"}}},
+		{name: "Only invalid parameters", value: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\ne"}}, result: contenttype.MediaType{Type: "a", Subtype: "b"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := contenttype.ParseMediaType(testCase.value.String())
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %q", err, testCase.value.String())
+			} else if !reflect.DeepEqual(result, testCase.result) {
+				t.Errorf("Invalid media type, got %v, expected %v", result, testCase.result)
+			}
+		})
+	}
+}
+
 func TestMediaTypeMIME(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -144,6 +221,56 @@ func TestMediaTypeMIME(t *testing.T) {
 	}
 }
 
+func TestMediaTypeSuffix(t *testing.T) {
+	testCases := []struct {
+		name   string
+		value  contenttype.MediaType
+		result string
+	}{
+		{name: "Empty media type", value: contenttype.MediaType{}, result: ""},
+		{name: "No suffix", value: contenttype.NewMediaType("application/json"), result: ""},
+		{name: "JSON suffix", value: contenttype.NewMediaType("application/vnd.api+json"), result: "json"},
+		{name: "XML suffix", value: contenttype.NewMediaType("application/soap+xml"), result: "xml"},
+		{name: "Multiple plus signs", value: contenttype.NewMediaType("application/a+b+cbor"), result: "cbor"},
+		{name: "Suffix wildcard", value: contenttype.NewMediaType("application/*+json"), result: "json"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.value.Suffix(); result != testCase.result {
+				t.Errorf("Invalid suffix, got %s, expected %s", result, testCase.result)
+			}
+		})
+	}
+}
+
+func TestMediaTypeTree(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       contenttype.MediaType
+		tree        contenttype.Tree
+		subtypeName string
+	}{
+		{name: "Empty media type", value: contenttype.MediaType{}, tree: contenttype.TreeStandard, subtypeName: ""},
+		{name: "Standard tree", value: contenttype.NewMediaType("application/json"), tree: contenttype.TreeStandard, subtypeName: "json"},
+		{name: "Standard tree with suffix", value: contenttype.NewMediaType("application/problem+json"), tree: contenttype.TreeStandard, subtypeName: "problem"},
+		{name: "Vendor tree", value: contenttype.NewMediaType("application/vnd.ms-excel"), tree: contenttype.TreeVendor, subtypeName: "ms-excel"},
+		{name: "Vendor tree with suffix", value: contenttype.NewMediaType("application/vnd.api+json"), tree: contenttype.TreeVendor, subtypeName: "api"},
+		{name: "Personal tree", value: contenttype.NewMediaType("application/prs.example.type"), tree: contenttype.TreePersonal, subtypeName: "example.type"},
+		{name: "Unregistered tree with dot", value: contenttype.NewMediaType("application/x.example"), tree: contenttype.TreeUnregistered, subtypeName: "example"},
+		{name: "Unregistered tree with hyphen", value: contenttype.NewMediaType("application/x-www-form-urlencoded"), tree: contenttype.TreeUnregistered, subtypeName: "www-form-urlencoded"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.value.Tree(); result != testCase.tree {
+				t.Errorf("Invalid tree, got %s, expected %s", result, testCase.tree)
+			}
+			if result := testCase.value.SubtypeName(); result != testCase.subtypeName {
+				t.Errorf("Invalid subtype name, got %s, expected %s", result, testCase.subtypeName)
+			}
+		})
+	}
+}
+
 func TestMediaTypeIsWildcard(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -176,10 +303,10 @@ func TestGetMediaType(t *testing.T) {
 		result contenttype.MediaType
 	}{
 		{name: "Empty header", header: "", result: contenttype.MediaType{}},
-		{name: "Type and subtype", header: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
-		{name: "Wildcard", header: "*/*", result: contenttype.MediaType{Type: "*", Subtype: "*", Parameters: contenttype.Parameters{}}},
-		{name: "Capital subtype", header: "Application/JSON", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
-		{name: "Space in front of type", header: " application/json ", result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}},
+		{name: "Type and subtype", header: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Wildcard", header: "*/*", result: contenttype.MediaType{Type: "*", Subtype: "*"}},
+		{name: "Capital subtype", header: "Application/JSON", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Space in front of type", header: " application/json ", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
 		{name: "Capital and parameter", header: "Application/XML;charset=utf-8", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"charset": "utf-8"}}},
 		{name: "Spaces around semicolon", header: "a/b ; c=d", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d"}}},
 		{name: "Spaces around semicolons", header: "a/b ; c=d ; e=f", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d", "e": "f"}}},
@@ -189,9 +316,14 @@ func TestGetMediaType(t *testing.T) {
 		{name: "Quoted parameter", header: "application/xml;foo=\"bar\" ", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"foo": "bar"}}},
 		{name: "Quoted empty parameter", header: "application/xml;foo=\"\" ", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"foo": ""}}},
 		{name: "Quoted pair", header: "application/xml;foo=\"\\\"b\" ", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"foo": "\"b"}}},
-		{name: "Whitespace after quoted parameter", header: "application/xml;foo=\"\\\"B\" ", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"foo": "\"b"}}},
+		{name: "Whitespace after quoted parameter", header: "application/xml;foo=\"\\\"B\" ", result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{"foo": "\"B"}}},
 		{name: "Plus in subtype", header: "a/b+c;a=b;c=d", result: contenttype.MediaType{Type: "a", Subtype: "b+c", Parameters: contenttype.Parameters{"a": "b", "c": "d"}}},
-		{name: "Capital parameter", header: "a/b;A=B", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"a": "b"}}},
+		{name: "Longest subtype", header: "a/" + strings.Repeat("b", 127), result: contenttype.MediaType{Type: "a", Subtype: strings.Repeat("b", 127)}},
+		{name: "Restricted name characters", header: "a/b!#$&-^_.+c", result: contenttype.MediaType{Type: "a", Subtype: "b!#$&-^_.+c"}},
+		{name: "Capital parameter", header: "a/b;A=B", result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"a": "B"}}},
+		{name: "Capital charset", header: "text/plain;Charset=UTF-8", result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{"charset": "utf-8"}}},
+		{name: "Multipart boundary", header: "multipart/form-data; boundary=AaB03x", result: contenttype.MediaType{Type: "multipart", Subtype: "form-data", Parameters: contenttype.Parameters{"boundary": "AaB03x"}}},
+		{name: "Quoted profile URI", header: "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"", result: contenttype.MediaType{Type: "application", Subtype: "ld+json", Parameters: contenttype.Parameters{"profile": "https://www.w3.org/ns/activitystreams"}}},
 	}
 
 	for _, testCase := range testCases {
@@ -233,6 +365,11 @@ func TestGetMediaTypeErrors(t *testing.T) {
 		{"Invalid character in quoted pair", "a/b;c=\"\\\x19\"", contenttype.ErrInvalidParameter},
 		{"No assignment after parameter", "a/b;c", contenttype.ErrInvalidParameter},
 		{"No semicolon before parameter", "a/b e", contenttype.ErrInvalidMediaType},
+		{"Subtype without alphanumeric character", "application/!!", contenttype.ErrInvalidMediaType},
+		{"Type starting with non-alphanumeric character", "-a/b", contenttype.ErrInvalidMediaType},
+		{"Subtype with non-restricted character", "a/b~c", contenttype.ErrInvalidMediaType},
+		{"Subtype longer than 127 characters", "a/" + strings.Repeat("b", 128), contenttype.ErrInvalidMediaType},
+		{"Suffix wildcard without suffix", "a/*+", contenttype.ErrInvalidMediaType},
 	}
 
 	for _, testCase := range testCases {
@@ -253,6 +390,73 @@ func TestGetMediaTypeErrors(t *testing.T) {
 	}
 }
 
+func TestGetMediaTypeStrict(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers []string
+		result  contenttype.MediaType
+	}{
+		{name: "No header", headers: nil, result: contenttype.MediaType{}},
+		{name: "Single header", headers: []string{"application/json"}, result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Identical headers", headers: []string{"application/json", "application/json"}, result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Equivalent headers", headers: []string{"text/plain; charset=UTF-8", "Text/Plain;charset=utf-8"}, result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{"charset": "utf-8"}}},
+		{name: "Comma in quoted parameter", headers: []string{"a/b; c=\"d,e\""}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d,e"}}},
+		{name: "Comma after quoted pair", headers: []string{"a/b; c=\"d\\\",e\""}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d\",e"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			for _, header := range testCase.headers {
+				request.Header.Add("Content-Type", header)
+			}
+
+			result, err := contenttype.GetMediaTypeStrict(request)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %v", err, testCase.headers)
+			} else if result.Type != testCase.result.Type || result.Subtype != testCase.result.Subtype {
+				t.Errorf("Invalid content type, got %s/%s, exptected %s/%s for %v", result.Type, result.Subtype, testCase.result.Type, testCase.result.Subtype, testCase.headers)
+			} else if !reflect.DeepEqual(result.Parameters, testCase.result.Parameters) {
+				t.Errorf("Wrong parameters, got %v, expected %v for %v", result.Parameters, testCase.result.Parameters, testCase.headers)
+			}
+		})
+	}
+}
+
+func TestGetMediaTypeStrictErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers []string
+		err     error
+	}{
+		{name: "Different headers", headers: []string{"application/json", "text/plain"}, err: contenttype.ErrAmbiguousMediaType},
+		{name: "Different parameters", headers: []string{"text/plain; charset=utf-8", "text/plain; charset=iso-8859-1"}, err: contenttype.ErrAmbiguousMediaType},
+		{name: "List of media types", headers: []string{"application/json, text/plain"}, err: contenttype.ErrAmbiguousMediaType},
+		{name: "List in second header", headers: []string{"application/json", "application/json,text/plain"}, err: contenttype.ErrAmbiguousMediaType},
+		{name: "Comma after quoted parameter", headers: []string{"a/b; c=\"d\", e/f"}, err: contenttype.ErrAmbiguousMediaType},
+		{name: "Invalid header", headers: []string{"application/"}, err: contenttype.ErrInvalidMediaType},
+		{name: "Invalid second header", headers: []string{"application/json", "application/"}, err: contenttype.ErrInvalidMediaType},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			for _, header := range testCase.headers {
+				request.Header.Add("Content-Type", header)
+			}
+
+			_, err := contenttype.GetMediaTypeStrict(request)
+			if err == nil {
+				t.Errorf("Expected an error for %v", testCase.headers)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %v", err, testCase.err, testCase.headers)
+			}
+		})
+	}
+}
+
 func TestGetAcceptableMediaType(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -263,73 +467,80 @@ func TestGetAcceptableMediaType(t *testing.T) {
 	}{
 		{name: "Empty header", availableMediaTypes: []contenttype.MediaType{
 			{"application", "json", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Type and subtype", header: "application/json", availableMediaTypes: []contenttype.MediaType{
 			{"application", "json", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Capitalized type and subtype", header: "Application/Json", availableMediaTypes: []contenttype.MediaType{
 			{"application", "json", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Multiple accept types", header: "text/plain,application/xml", availableMediaTypes: []contenttype.MediaType{
 			{"text", "plain", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Multiple accept types, second available", header: "text/plain,application/xml", availableMediaTypes: []contenttype.MediaType{
 			{"application", "xml", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "xml", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Accept weight", header: "text/plain;q=1.0", availableMediaTypes: []contenttype.MediaType{
 			{"text", "plain", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Wildcard", header: "*/*", availableMediaTypes: []contenttype.MediaType{
 			{"application", "json", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Wildcard subtype", header: "application/*", availableMediaTypes: []contenttype.MediaType{
 			{"application", "json", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Weight with dot", header: "a/b;q=1.", availableMediaTypes: []contenttype.MediaType{
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Multiple weights", header: "a/b;q=0.1,c/d;q=0.2", availableMediaTypes: []contenttype.MediaType{
 			{"a", "b", contenttype.Parameters{}},
 			{"c", "d", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "c", Subtype: "d", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "c", Subtype: "d", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Multiple weights and default weight", header: "a/b;q=0.2,c/d;q=0.2", availableMediaTypes: []contenttype.MediaType{
 			{"a", "b", contenttype.Parameters{}},
 			{"c", "d", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Wildcard subtype and weight", header: "a/*;q=0.2,a/c", availableMediaTypes: []contenttype.MediaType{
 			{"a", "b", contenttype.Parameters{}},
 			{"a", "c", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "c", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "c", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Different accept order", header: "a/b,a/a", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Wildcard subtype with multiple available types", header: "a/*", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Wildcard subtype against weighted type", header: "a/a;q=0.2,a/*", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Media type parameter", header: "a/a;q=0.2,a/a;c=d", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "a", contenttype.Parameters{"c": "d"}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{"c": "d"}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{"c": "d"}}, extensionParameters: nil},
 		{name: "Weight and media type parameter", header: "a/b;q=1;e=e", availableMediaTypes: []contenttype.MediaType{
 			{"a", "b", contenttype.Parameters{}},
 		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{"e": "e"}},
 		{header: "a/*,a/a;q=0", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
+		{name: "Range that is not a restricted name", header: "application/json, a/b~c;q=0.1", availableMediaTypes: []contenttype.MediaType{
+			{"application", "json", contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
+		{name: "Matched range that is not a restricted name", header: "text/html;q=0.5, -a/b~c", availableMediaTypes: []contenttype.MediaType{
+			{"text", "html", contenttype.Parameters{}},
+			{"-a", "b~c", contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "-a", Subtype: "b~c", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Maximum length weight", header: "a/a;q=0.001,a/b;q=0.002", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
 			{"a", "b", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 		{name: "Spaces around comma", header: "a/a;q=0.1 , a/b , a/c", availableMediaTypes: []contenttype.MediaType{
 			{"a", "a", contenttype.Parameters{}},
-		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{}}, extensionParameters: contenttype.Parameters{}},
+		}, result: contenttype.MediaType{Type: "a", Subtype: "a", Parameters: contenttype.Parameters{}}, extensionParameters: nil},
 	}
 
 	for _, testCase := range testCases {
@@ -355,6 +566,81 @@ func TestGetAcceptableMediaType(t *testing.T) {
 	}
 }
 
+func TestGetAcceptableMediaTypeMultipleHeaders(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+		contenttype.NewMediaType("text/html"),
+	}
+
+	testCases := []struct {
+		name    string
+		headers []string
+		result  contenttype.MediaType
+	}{
+		{name: "Single field line", headers: []string{"text/html, application/xml"}, result: contenttype.NewMediaType("text/html")},
+		{name: "Type in second field line", headers: []string{"image/png", "application/xml"}, result: contenttype.NewMediaType("application/xml")},
+		{name: "Higher weight in second field line", headers: []string{"application/json;q=0.5", "text/html"}, result: contenttype.NewMediaType("text/html")},
+		{name: "Exclusion in second field line", headers: []string{"*/*", "application/json;q=0"}, result: contenttype.NewMediaType("application/xml")},
+		{name: "Empty field line", headers: []string{"", "application/xml"}, result: contenttype.NewMediaType("application/xml")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			for _, header := range testCase.headers {
+				request.Header.Add("Accept", header)
+			}
+
+			result, _, err := contenttype.GetAcceptableMediaType(request, availableMediaTypes)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %v", err, testCase.headers)
+			} else if !result.EqualsMIME(testCase.result) {
+				t.Errorf("Invalid content type, got %s, expected %s for %v", result, testCase.result, testCase.headers)
+			}
+		})
+	}
+}
+
+func TestGetAcceptableMediaTypeFromHeaderWithOptions(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/problem+json"),
+		contenttype.NewMediaType("application/vnd.api+json"),
+		contenttype.NewMediaType("application/soap+xml"),
+	}
+
+	testCases := []struct {
+		name    string
+		header  string
+		options contenttype.NegotiationOptions
+		result  contenttype.MediaType
+		err     error
+	}{
+		{name: "Suffix wildcard", header: "application/*+xml", options: contenttype.NegotiationOptions{}, result: availableMediaTypes[2]},
+		{name: "Suffix wildcard before wildcard", header: "application/*;q=0.5,application/*+json", options: contenttype.NegotiationOptions{}, result: availableMediaTypes[0]},
+		{name: "Suffix without option", header: "application/json", options: contenttype.NegotiationOptions{}, err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Suffix with option", header: "application/json", options: contenttype.NegotiationOptions{MatchSuffix: true}, result: availableMediaTypes[0]},
+		{name: "Exact type before suffix", header: "application/json,application/vnd.api+json;q=0", options: contenttype.NegotiationOptions{MatchSuffix: true}, result: availableMediaTypes[0]},
+		{name: "Exact type weight before suffix weight", header: "application/json;q=0.5,application/vnd.api+json", options: contenttype.NegotiationOptions{MatchSuffix: true}, result: availableMediaTypes[1]},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, _, err := contenttype.GetAcceptableMediaTypeFromHeaderWithOptions(testCase.header, availableMediaTypes, testCase.options)
+			if testCase.err != nil {
+				if !errors.Is(err, testCase.err) {
+					t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if !result.Equal(testCase.result) {
+				t.Errorf("Invalid media type, got %s, expected %s for %s", result, testCase.result, testCase.header)
+			}
+		})
+	}
+}
+
 func TestGetAcceptableMediaTypeErrors(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -442,6 +728,19 @@ func TestMediaTypeEqual(t *testing.T) {
 	}
 }
 
+func TestMediaTypeEqualEmptyParameters(t *testing.T) {
+	withNil := contenttype.MediaType{Type: "application", Subtype: "json"}
+	withEmpty := contenttype.MediaType{Type: "application", Subtype: "json", Parameters: contenttype.Parameters{}}
+
+	if !withNil.Equal(withEmpty) || !withEmpty.Equal(withNil) {
+		t.Errorf("Media types with nil and empty parameters are not equal")
+	}
+
+	if withNil.Equal(contenttype.NewMediaType("application/json;a=b")) {
+		t.Errorf("Media types with and without parameters are equal")
+	}
+}
+
 func TestMediaTypeEqualsMIME(t *testing.T) {
 	// create a map of items to turn into a permutation, these should all be
 	// different
@@ -511,6 +810,11 @@ func TestMediaTypeMatches(t *testing.T) {
 		{"text/plain doesn't match application/*", instSimple, instAppWildcard, false},
 		{"text/* doesn't match application/*", instTextWildcard, instAppWildcard, false},
 		{"*/* matches application/*", instWildcard, instAppWildcard, true},
+		{"application/*+json matches application/vnd.api+json", contenttype.NewMediaType("application/*+json"), contenttype.NewMediaType("application/vnd.api+json"), true},
+		{"application/vnd.api+json matches application/*+json", contenttype.NewMediaType("application/vnd.api+json"), contenttype.NewMediaType("application/*+json"), true},
+		{"application/*+json doesn't match application/json", contenttype.NewMediaType("application/*+json"), instJSON, false},
+		{"application/*+json doesn't match application/soap+xml", contenttype.NewMediaType("application/*+json"), contenttype.NewMediaType("application/soap+xml"), false},
+		{"application/json doesn't match application/vnd.api+json", instJSON, contenttype.NewMediaType("application/vnd.api+json"), false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -545,3 +849,82 @@ func TestMediaTypeMatchesAny(t *testing.T) {
 		})
 	}
 }
+
+var benchmarkMediaTypes = []contenttype.MediaType{
+	contenttype.NewMediaType("application/json"),
+	contenttype.NewMediaType("application/xml"),
+	contenttype.NewMediaType("application/vnd.api+json"),
+	contenttype.NewMediaType("application/hal+json"),
+	contenttype.NewMediaType("application/problem+json"),
+	contenttype.NewMediaType("application/ld+json"),
+	contenttype.NewMediaType("application/atom+xml"),
+	contenttype.NewMediaType("application/rss+xml"),
+	contenttype.NewMediaType("application/x-yaml"),
+	contenttype.NewMediaType("application/msgpack"),
+	contenttype.NewMediaType("application/cbor"),
+	contenttype.NewMediaType("application/protobuf"),
+	contenttype.NewMediaType("text/plain"),
+	contenttype.NewMediaType("text/csv"),
+	contenttype.NewMediaType("text/html"),
+	contenttype.NewMediaType("application/vnd.github+json"),
+}
+
+var benchmarkAcceptHeaders = []struct {
+	name   string
+	header string
+}{
+	{name: "Chrome", header: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"},
+	{name: "Firefox", header: "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"},
+	{name: "Axios", header: "application/json, text/plain, */*"},
+	{name: "GitHub API", header: "application/vnd.github+json"},
+	{name: "Wildcard", header: "*/*"},
+}
+
+func TestParseMediaTypeAllocations(t *testing.T) {
+	// media types without parameters don't need a Parameters map
+	allocations := testing.AllocsPerRun(100, func() {
+		if _, err := contenttype.ParseMediaType("application/json"); err != nil {
+			t.Errorf("Unexpected error \"%v\"", err)
+		}
+	})
+
+	if allocations != 0 {
+		t.Errorf("Invalid number of allocations, got %v, expected 0", allocations)
+	}
+}
+
+func BenchmarkParseMediaType(b *testing.B) {
+	benchmarks := []struct {
+		name   string
+		header string
+	}{
+		{name: "Plain", header: "application/json"},
+		{name: "Charset", header: "application/json; charset=utf-8"},
+		{name: "Multipart", header: "multipart/form-data; boundary=----WebKitFormBoundary7MA4YWxkTrZu0gW"},
+		{name: "Quoted", header: "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\""},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := contenttype.ParseMediaType(benchmark.header); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetAcceptableMediaTypeFromHeader(b *testing.B) {
+	for _, benchmark := range benchmarkAcceptHeaders {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := contenttype.GetAcceptableMediaTypeFromHeader(benchmark.header, benchmarkMediaTypes); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
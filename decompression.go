@@ -0,0 +1,166 @@
+package contenttype
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxContentEncodings is the maximal number of content codings applied to a request body.
+const maxContentEncodings = 2
+
+// DecompressionOptions are the options of the decompression handler.
+type DecompressionOptions struct {
+	// MaxSize is the maximal size of the decompressed request body in bytes, zero means no limit.
+	MaxSize int64
+}
+
+// DefaultDecompressionOptions returns the options used by DecompressHandler: decompressed request bodies are
+// limited to 10 MiB.
+func DefaultDecompressionOptions() DecompressionOptions {
+	return DecompressionOptions{
+		MaxSize: 10 << 20,
+	}
+}
+
+// DecompressHandler returns a handler that decodes gzip and deflate request bodies according to the
+// Content-Encoding header before passing the request to the given handler using the DefaultDecompressionOptions.
+func DecompressHandler(handler http.Handler) http.Handler {
+	return DecompressHandlerWithOptions(handler, DefaultDecompressionOptions())
+}
+
+// DecompressHandlerWithOptions returns a handler that decodes gzip and deflate request bodies according to the
+// Content-Encoding header before passing the request to the given handler.
+// The content codings are removed in the reverse order of their application and the Content-Encoding and
+// Content-Length headers are removed from the request. Reading more than MaxSize decompressed bytes fails.
+// Requests with unsupported content codings or more than two stacked content codings are rejected with
+// 415 Unsupported Media Type and an Accept-Encoding header listing the supported ones, malformed encoded bodies are
+// rejected with 400 Bad Request.
+func DecompressHandlerWithOptions(handler http.Handler, options DecompressionOptions) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		encodings, err := getContentEncodings(request.Header)
+		if err != nil {
+			http.Error(responseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if len(encodings) == 0 {
+			handler.ServeHTTP(responseWriter, request)
+			return
+		}
+
+		// every content coding adds a decompressor before any bytes are read
+		if len(encodings) > maxContentEncodings {
+			responseWriter.Header().Set("Accept-Encoding", EncodingGzip+", "+EncodingDeflate)
+			http.Error(responseWriter, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+			return
+		}
+
+		for _, encoding := range encodings {
+			if encoding != EncodingGzip && encoding != EncodingDeflate {
+				// RFC 7694, 3. Advertising Supported Content Codings
+				responseWriter.Header().Set("Accept-Encoding", EncodingGzip+", "+EncodingDeflate)
+				http.Error(responseWriter, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+
+		body := &decodedBody{
+			closers: []io.Closer{request.Body},
+		}
+		body.reader = request.Body
+
+		for i := len(encodings) - 1; i >= 0; i-- {
+			var decoder io.ReadCloser
+			switch encodings[i] {
+			case EncodingGzip:
+				decoder, err = gzip.NewReader(body.reader)
+			case EncodingDeflate:
+				// RFC 7230, 4.2.2. Deflate Coding
+				decoder, err = zlib.NewReader(body.reader)
+			}
+
+			if err != nil {
+				body.Close()
+				http.Error(responseWriter, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			body.reader = decoder
+			body.closers = append(body.closers, decoder)
+		}
+
+		request.Header.Del("Content-Encoding")
+		request.Header.Del("Content-Length")
+		request.ContentLength = -1
+
+		if options.MaxSize > 0 {
+			request.Body = http.MaxBytesReader(responseWriter, body, options.MaxSize)
+		} else {
+			request.Body = body
+		}
+
+		handler.ServeHTTP(responseWriter, request)
+	})
+}
+
+type decodedBody struct {
+	reader  io.Reader
+	closers []io.Closer
+}
+
+func (body *decodedBody) Read(data []byte) (int, error) {
+	return body.reader.Read(data)
+}
+
+func (body *decodedBody) Close() error {
+	var result error
+	for i := len(body.closers) - 1; i >= 0; i-- {
+		if err := body.closers[i].Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+func getContentEncodings(header http.Header) ([]string, error) {
+	// RFC 7231, 3.1.2.2. Content-Encoding
+	var encodings []string
+
+	for _, s := range header.Values("Content-Encoding") {
+		for {
+			s = skipWhitespaces(s)
+			if len(s) == 0 {
+				break
+			}
+
+			if s[0] != ',' {
+				encoding, remaining, consumed := consumeToken(s)
+				if !consumed {
+					return nil, ErrInvalidEncoding
+				}
+
+				encoding = normalizeEncoding(strings.ToLower(encoding))
+				if encoding != EncodingIdentity {
+					encodings = append(encodings, encoding)
+				}
+
+				s = skipWhitespaces(remaining)
+				if len(s) == 0 {
+					break
+				}
+
+				if s[0] != ',' {
+					return nil, ErrInvalidEncoding
+				}
+			}
+
+			s = s[1:]
+		}
+	}
+
+	return encodings, nil
+}
@@ -0,0 +1,170 @@
+package contenttype_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func encodeBody(t *testing.T, body []byte, encoding string) []byte {
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip", "x-gzip":
+		writer = gzip.NewWriter(&buffer)
+	case "deflate":
+		writer = zlib.NewWriter(&buffer)
+	default:
+		return body
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestDecompressHandler(t *testing.T) {
+	testCases := []struct {
+		name            string
+		contentEncoding []string
+		encodings       []string
+		body            string
+	}{
+		{name: "No encoding", contentEncoding: nil, encodings: nil, body: "Hello, world!"},
+		{name: "Identity", contentEncoding: []string{"identity"}, encodings: nil, body: "Hello, world!"},
+		{name: "Gzip", contentEncoding: []string{"gzip"}, encodings: []string{"gzip"}, body: "Hello, world!"},
+		{name: "Legacy x-gzip", contentEncoding: []string{"x-gzip"}, encodings: []string{"gzip"}, body: "Hello, world!"},
+		{name: "Upper-case gzip", contentEncoding: []string{"GZIP"}, encodings: []string{"gzip"}, body: "Hello, world!"},
+		{name: "Deflate", contentEncoding: []string{"deflate"}, encodings: []string{"deflate"}, body: "Hello, world!"},
+		{name: "Encoding list", contentEncoding: []string{"gzip, deflate"}, encodings: []string{"gzip", "deflate"}, body: "Hello, world!"},
+		{name: "Multiple headers", contentEncoding: []string{"deflate", "gzip"}, encodings: []string{"deflate", "gzip"}, body: "Hello, world!"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			body := []byte(testCase.body)
+			for _, encoding := range testCase.encodings {
+				body = encodeBody(t, body, encoding)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "http://test.test", bytes.NewReader(body))
+			for _, contentEncoding := range testCase.contentEncoding {
+				request.Header.Add("Content-Encoding", contentEncoding)
+			}
+
+			var result []byte
+			handler := contenttype.DecompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(testCase.encodings) > 0 && len(r.Header.Get("Content-Encoding")) > 0 {
+					t.Errorf("Unexpected Content-Encoding %s", r.Header.Get("Content-Encoding"))
+				}
+
+				var err error
+				if result, err = ioutil.ReadAll(r.Body); err != nil {
+					t.Errorf("Unexpected error \"%v\"", err)
+				}
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Invalid status code, got %d, expected %d", recorder.Code, http.StatusOK)
+			}
+
+			if string(result) != testCase.body {
+				t.Errorf("Invalid body, got %s, expected %s", result, testCase.body)
+			}
+		})
+	}
+}
+
+func TestDecompressHandlerErrors(t *testing.T) {
+	testCases := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+		statusCode      int
+		acceptEncoding  string
+	}{
+		{name: "Unsupported encoding", contentEncoding: "br", body: []byte("Hello, world!"), statusCode: http.StatusUnsupportedMediaType, acceptEncoding: "gzip, deflate"},
+		{name: "Unsupported encoding in list", contentEncoding: "gzip, zstd", body: []byte("Hello, world!"), statusCode: http.StatusUnsupportedMediaType, acceptEncoding: "gzip, deflate"},
+		{name: "Too many encodings", contentEncoding: "gzip, gzip, gzip", body: []byte("Hello, world!"), statusCode: http.StatusUnsupportedMediaType, acceptEncoding: "gzip, deflate"},
+		{name: "Invalid encoding", contentEncoding: "gzip;q=1", body: []byte("Hello, world!"), statusCode: http.StatusBadRequest},
+		{name: "Invalid gzip body", contentEncoding: "gzip", body: []byte("Hello, world!"), statusCode: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "http://test.test", bytes.NewReader(testCase.body))
+			request.Header.Set("Content-Encoding", testCase.contentEncoding)
+
+			handler := contenttype.DecompressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("Unexpected call of the handler")
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.statusCode {
+				t.Errorf("Invalid status code, got %d, expected %d", recorder.Code, testCase.statusCode)
+			}
+
+			if acceptEncoding := recorder.Header().Get("Accept-Encoding"); acceptEncoding != testCase.acceptEncoding {
+				t.Errorf("Invalid Accept-Encoding, got %s, expected %s", acceptEncoding, testCase.acceptEncoding)
+			}
+		})
+	}
+}
+
+func TestDecompressHandlerWithOptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		maxSize int64
+		size    int
+		valid   bool
+	}{
+		{name: "Below limit", maxSize: 1024, size: 1000, valid: true},
+		{name: "At limit", maxSize: 1024, size: 1024, valid: true},
+		{name: "Above limit", maxSize: 1024, size: 1025, valid: false},
+		{name: "Zip bomb", maxSize: 1024, size: 10 << 20, valid: false},
+		{name: "No limit", maxSize: 0, size: 10 << 20, valid: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			body := encodeBody(t, []byte(strings.Repeat("a", testCase.size)), "gzip")
+
+			request := httptest.NewRequest(http.MethodPost, "http://test.test", bytes.NewReader(body))
+			request.Header.Set("Content-Encoding", "gzip")
+
+			options := contenttype.DecompressionOptions{MaxSize: testCase.maxSize}
+			handler := contenttype.DecompressHandlerWithOptions(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				result, err := ioutil.ReadAll(r.Body)
+				if testCase.valid {
+					if err != nil {
+						t.Errorf("Unexpected error \"%v\"", err)
+					} else if len(result) != testCase.size {
+						t.Errorf("Invalid body size, got %d, expected %d", len(result), testCase.size)
+					}
+				} else if err == nil {
+					t.Errorf("Expected an error")
+				}
+			}), options)
+
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package contenttype
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (mediaType MediaType) MarshalText() ([]byte, error) {
+	return []byte(mediaType.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// An empty text results in an empty MediaType.
+func (mediaType *MediaType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*mediaType = MediaType{}
+		return nil
+	}
+
+	result, err := ParseMediaType(string(text))
+	if err != nil {
+		return err
+	}
+
+	*mediaType = result
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (mediaType MediaType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mediaType.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (mediaType *MediaType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return mediaType.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface.
+// A NULL value results in an empty MediaType.
+func (mediaType *MediaType) Scan(src interface{}) error {
+	text, err := getScannedText(src)
+	if err != nil {
+		return err
+	}
+
+	return mediaType.UnmarshalText(text)
+}
+
+// Value implements the driver.Valuer interface.
+// An empty MediaType is stored as NULL.
+func (mediaType MediaType) Value() (driver.Value, error) {
+	if len(mediaType.Type) == 0 && len(mediaType.Subtype) == 0 {
+		return nil, nil
+	}
+
+	return mediaType.String(), nil
+}
+
+// Set implements the flag.Value interface.
+func (mediaType *MediaType) Set(s string) error {
+	return mediaType.UnmarshalText([]byte(s))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (language Language) MarshalText() ([]byte, error) {
+	return []byte(language.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// An empty text results in an empty Language.
+func (language *Language) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*language = Language{}
+		return nil
+	}
+
+	result, err := ParseLanguage(string(text))
+	if err != nil {
+		return err
+	}
+
+	*language = result
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (language Language) MarshalJSON() ([]byte, error) {
+	return json.Marshal(language.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (language *Language) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return language.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface.
+// A NULL value results in an empty Language.
+func (language *Language) Scan(src interface{}) error {
+	text, err := getScannedText(src)
+	if err != nil {
+		return err
+	}
+
+	return language.UnmarshalText(text)
+}
+
+// Value implements the driver.Valuer interface.
+// An empty Language is stored as NULL.
+func (language Language) Value() (driver.Value, error) {
+	if len(language.Language) == 0 {
+		return nil, nil
+	}
+
+	return language.String(), nil
+}
+
+// Set implements the flag.Value interface.
+func (language *Language) Set(s string) error {
+	return language.UnmarshalText([]byte(s))
+}
+
+func getScannedText(src interface{}) ([]byte, error) {
+	switch value := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("cannot scan %T", src)
+	}
+}
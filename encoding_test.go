@@ -0,0 +1,194 @@
+package contenttype_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+var (
+	_ encoding.TextMarshaler   = contenttype.MediaType{}
+	_ encoding.TextUnmarshaler = &contenttype.MediaType{}
+	_ json.Marshaler           = contenttype.MediaType{}
+	_ json.Unmarshaler         = &contenttype.MediaType{}
+	_ sql.Scanner              = &contenttype.MediaType{}
+	_ driver.Valuer            = contenttype.MediaType{}
+	_ flag.Value               = &contenttype.MediaType{}
+
+	_ encoding.TextMarshaler   = contenttype.Language{}
+	_ encoding.TextUnmarshaler = &contenttype.Language{}
+	_ json.Marshaler           = contenttype.Language{}
+	_ json.Unmarshaler         = &contenttype.Language{}
+	_ sql.Scanner              = &contenttype.Language{}
+	_ driver.Valuer            = contenttype.Language{}
+	_ flag.Value               = &contenttype.Language{}
+)
+
+func TestJSON(t *testing.T) {
+	type document struct {
+		MediaType contenttype.MediaType `json:"mediaType"`
+		Language  contenttype.Language  `json:"language"`
+	}
+
+	testCases := []struct {
+		name   string
+		value  document
+		result string
+	}{
+		{name: "Empty values", value: document{}, result: `{"mediaType":"","language":""}`},
+		{name: "Values", value: document{
+			MediaType: contenttype.MediaType{Type: "text", Subtype: "plain", Parameters: contenttype.Parameters{"charset": "utf-8"}},
+			Language:  contenttype.Language{Language: "de", Region: "CH"},
+		}, result: `{"mediaType":"text/plain;charset=utf-8","language":"de-CH"}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := json.Marshal(testCase.value)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			} else if string(data) != testCase.result {
+				t.Fatalf("Invalid JSON, got %s, expected %s", data, testCase.result)
+			}
+
+			var result document
+			if err := json.Unmarshal(data, &result); err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %s", err, data)
+			} else if result.MediaType.String() != testCase.value.MediaType.String() || result.Language != testCase.value.Language {
+				t.Errorf("Invalid document, got %v, expected %v", result, testCase.value)
+			}
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value encoding.TextUnmarshaler
+		text  string
+		err   error
+	}{
+		{name: "Invalid media type", value: &contenttype.MediaType{}, text: "a/", err: contenttype.ErrInvalidMediaType},
+		{name: "Invalid parameter", value: &contenttype.MediaType{}, text: "a/b;c", err: contenttype.ErrInvalidParameter},
+		{name: "Invalid language", value: &contenttype.Language{}, text: "xx-YY", err: contenttype.ErrInvalidLanguage},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.value.UnmarshalText([]byte(testCase.text))
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.text)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.text)
+			}
+		})
+	}
+}
+
+func TestMediaTypeSQL(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    interface{}
+		result contenttype.MediaType
+	}{
+		{name: "NULL", src: nil, result: contenttype.MediaType{}},
+		{name: "String", src: "application/json", result: contenttype.MediaType{Type: "application", Subtype: "json"}},
+		{name: "Bytes", src: []byte("a/b;c=d"), result: contenttype.MediaType{Type: "a", Subtype: "b", Parameters: contenttype.Parameters{"c": "d"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var result contenttype.MediaType
+			if err := result.Scan(testCase.src); err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %v", err, testCase.src)
+			} else if !reflect.DeepEqual(result, testCase.result) {
+				t.Fatalf("Invalid media type, got %v, expected %v", result, testCase.result)
+			}
+
+			value, err := result.Value()
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			}
+
+			var again contenttype.MediaType
+			if err := again.Scan(value); err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %v", err, value)
+			} else if !reflect.DeepEqual(again, testCase.result) {
+				t.Errorf("Invalid media type, got %v, expected %v", again, testCase.result)
+			}
+		})
+	}
+
+	var mediaType contenttype.MediaType
+	if err := mediaType.Scan(42); err == nil {
+		t.Errorf("Expected an error for an integer")
+	}
+}
+
+func TestLanguageSQL(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    interface{}
+		result contenttype.Language
+	}{
+		{name: "NULL", src: nil, result: contenttype.Language{}},
+		{name: "String", src: "zh-Hant-TW", result: contenttype.Language{Language: "zh", Script: "Hant", Region: "TW"}},
+		{name: "Bytes", src: []byte("lv-lv"), result: contenttype.Language{Language: "lv", Region: "LV"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var result contenttype.Language
+			if err := result.Scan(testCase.src); err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %v", err, testCase.src)
+			} else if result != testCase.result {
+				t.Fatalf("Invalid language, got %v, expected %v", result, testCase.result)
+			}
+
+			value, err := result.Value()
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			}
+
+			var again contenttype.Language
+			if err := again.Scan(value); err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %v", err, value)
+			} else if again != testCase.result {
+				t.Errorf("Invalid language, got %v, expected %v", again, testCase.result)
+			}
+		})
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var mediaType contenttype.MediaType
+	var language contenttype.Language
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Var(&mediaType, "type", "media type")
+	flagSet.Var(&language, "language", "language")
+
+	if err := flagSet.Parse([]string{"-type", "Text/HTML; charset=UTF-8", "-language", "en-us"}); err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	if result := mediaType.String(); result != "text/html;charset=utf-8" {
+		t.Errorf("Invalid media type, got %s, expected %s", result, "text/html;charset=utf-8")
+	}
+	if result := language.String(); result != "en-US" {
+		t.Errorf("Invalid language, got %s, expected %s", result, "en-US")
+	}
+
+	flagSet.SetOutput(ioutil.Discard)
+	if err := flagSet.Parse([]string{"-type", "text"}); err == nil {
+		t.Errorf("Expected an error for an invalid media type")
+	}
+}
@@ -5,6 +5,8 @@ import "errors"
 var (
 	// ErrInvalidMediaType is returned when the media type in the Content-Type or Accept header is syntactically invalid.
 	ErrInvalidMediaType = errors.New("invalid media type")
+	// ErrAmbiguousMediaType is returned when the request contains conflicting Content-Type values or a list of media types.
+	ErrAmbiguousMediaType = errors.New("ambiguous media type")
 	// ErrInvalidMediaRange is returned when the range of media types in the Content-Type or Accept header is syntactically invalid.
 	ErrInvalidMediaRange = errors.New("invalid media range")
 	// ErrInvalidParameter is returned when the media type parameter in the Content-Type or Accept header is syntactically invalid.
@@ -17,6 +19,26 @@ var (
 	ErrNoAvailableTypeGiven = errors.New("no available type given")
 	// ErrInvalidWeight is returned when the media type weight in Accept header is syntactically invalid.
 	ErrInvalidWeight = errors.New("invalid weight")
+	// ErrInvalidVersion is returned when the version of a media type in Accept header is syntactically invalid.
+	ErrInvalidVersion = errors.New("invalid version")
+	// ErrInvalidCharset is returned when the charset in Accept-Charset header is syntactically invalid.
+	ErrInvalidCharset = errors.New("invalid charset")
+	// ErrNoAcceptableCharsetFound is returned when Accept-Charset header contains only charsets that are not in the available charset list.
+	ErrNoAcceptableCharsetFound = errors.New("no acceptable charset found")
+	// ErrNoAvailableCharsetGiven is returned when the available charset list is empty.
+	ErrNoAvailableCharsetGiven = errors.New("no available charset given")
+	// ErrInvalidEncoding is returned when the content coding in Accept-Encoding header is syntactically invalid.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	// ErrNoAcceptableEncodingFound is returned when Accept-Encoding header excludes all the available content codings.
+	ErrNoAcceptableEncodingFound = errors.New("no acceptable encoding found")
+	// ErrNoAvailableEncodingGiven is returned when the available content coding list is empty.
+	ErrNoAvailableEncodingGiven = errors.New("no available encoding given")
+	// ErrNoAcceptableVariantFound is returned when the request headers exclude all the available variants.
+	ErrNoAcceptableVariantFound = errors.New("no acceptable variant found")
+	// ErrNoAvailableVariantGiven is returned when the available variant list is empty.
+	ErrNoAvailableVariantGiven = errors.New("no available variant given")
 	// ErrInvalidLanguage is returned when the language is syntactically invalid.
 	ErrInvalidLanguage = errors.New("invalid language")
+	// ErrMessageNotFound is returned when neither the language nor any of its fallbacks has a message for the key.
+	ErrMessageNotFound = errors.New("message not found")
 )
@@ -5,1434 +5,1646 @@ import (
 	"unicode"
 )
 
-// List of ISO 639 set 1 language codes
-var languageSet1 = map[string]string{
-	"aa": "aar",
-	"ab": "abk",
-	"ae": "ave",
-	"af": "afr",
-	"ak": "aka",
-	"am": "amh",
-	"an": "arg",
-	"ar": "ara",
-	"as": "asm",
-	"av": "ava",
-	"ay": "aym",
-	"az": "aze",
-	"ba": "bak",
-	"be": "bel",
-	"bg": "bul",
-	"bh": "bih",
-	"bi": "bis",
-	"bm": "bam",
-	"bn": "ben",
-	"bo": "bod",
-	"br": "bre",
-	"bs": "bos",
-	"ca": "cat",
-	"ce": "che",
-	"ch": "cha",
-	"co": "cos",
-	"cr": "cre",
-	"cs": "ces",
-	"cu": "chu",
-	"cv": "chv",
-	"cy": "cym",
-	"da": "dan",
-	"de": "deu",
-	"dv": "div",
-	"dz": "dzo",
-	"ee": "ewe",
-	"el": "ell",
-	"en": "eng",
-	"eo": "epo",
-	"es": "spa",
-	"et": "est",
-	"eu": "eus",
-	"fa": "fas",
-	"ff": "ful",
-	"fi": "fin",
-	"fj": "fij",
-	"fo": "fao",
-	"fr": "fra",
-	"fy": "fry",
-	"ga": "gle",
-	"gd": "gla",
-	"gl": "glg",
-	"gn": "grn",
-	"gu": "guj",
-	"gv": "glv",
-	"ha": "hau",
-	"he": "heb",
-	"hi": "hin",
-	"ho": "hmo",
-	"hr": "hrv",
-	"ht": "hat",
-	"hu": "hun",
-	"hy": "hye",
-	"hz": "her",
-	"ia": "ina",
-	"id": "ind",
-	"ie": "ile",
-	"ig": "ibo",
-	"ii": "iii",
-	"ik": "ipk",
-	"io": "ido",
-	"is": "isl",
-	"it": "ita",
-	"iu": "iku",
-	"ja": "jpn",
-	"jv": "jav",
-	"ka": "kat",
-	"kg": "kon",
-	"ki": "kik",
-	"kj": "kua",
-	"kk": "kaz",
-	"kl": "kal",
-	"km": "khm",
-	"kn": "kan",
-	"ko": "kor",
-	"kr": "kau",
-	"ks": "kas",
-	"ku": "kur",
-	"kv": "kom",
-	"kw": "cor",
-	"ky": "kir",
-	"la": "lat",
-	"lb": "ltz",
-	"lg": "lug",
-	"li": "lim",
-	"ln": "lin",
-	"lo": "lao",
-	"lt": "lit",
-	"lu": "lub",
-	"lv": "lav",
-	"mg": "mlg",
-	"mh": "mah",
-	"mi": "mri",
-	"mk": "mkd",
-	"ml": "mal",
-	"mn": "mon",
-	"mr": "mar",
-	"ms": "msa",
-	"mt": "mlt",
-	"my": "mya",
-	"na": "nau",
-	"nb": "nob",
-	"nd": "nde",
-	"ne": "nep",
-	"ng": "ndo",
-	"nl": "nld",
-	"nn": "nno",
-	"no": "nor",
-	"nr": "nbl",
-	"nv": "nav",
-	"ny": "nya",
-	"oc": "oci",
-	"oj": "oji",
-	"om": "orm",
-	"or": "ori",
-	"os": "oss",
-	"pa": "pan",
-	"pi": "pli",
-	"pl": "pol",
-	"ps": "pus",
-	"pt": "por",
-	"qu": "que",
-	"rm": "roh",
-	"rn": "run",
-	"ro": "ron",
-	"ru": "rus",
-	"rw": "kin",
-	"sa": "san",
-	"sc": "srd",
-	"sd": "snd",
-	"se": "sme",
-	"sg": "sag",
-	"si": "sin",
-	"sk": "slk",
-	"sl": "slv",
-	"sm": "smo",
-	"sn": "sna",
-	"so": "som",
-	"sq": "sqi",
-	"sr": "srp",
-	"ss": "ssw",
-	"st": "sot",
-	"su": "sun",
-	"sv": "swe",
-	"sw": "swa",
-	"ta": "tam",
-	"te": "tel",
-	"tg": "tgk",
-	"th": "tha",
-	"ti": "tir",
-	"tk": "tuk",
-	"tl": "tgl",
-	"tn": "tsn",
-	"to": "ton",
-	"tr": "tur",
-	"ts": "tso",
-	"tt": "tat",
-	"tw": "twi",
-	"ty": "tah",
-	"ug": "uig",
-	"uk": "ukr",
-	"ur": "urd",
-	"uz": "uzb",
-	"ve": "ven",
-	"vi": "vie",
-	"vo": "vol",
-	"wa": "wln",
-	"wo": "wol",
-	"xh": "xho",
-	"yi": "yid",
-	"yo": "yor",
-	"za": "zha",
-	"zh": "zho",
-	"zu": "zul",
-}
+// List of ISO 639 set 1 language codes, stored as sorted records of the two-letter code followed by
+// the ISO 639 set 2 terminology code
+const languageSet1 = "" +
+	"aaaar" +
+	"ababk" +
+	"aeave" +
+	"afafr" +
+	"akaka" +
+	"amamh" +
+	"anarg" +
+	"arara" +
+	"asasm" +
+	"avava" +
+	"ayaym" +
+	"azaze" +
+	"babak" +
+	"bebel" +
+	"bgbul" +
+	"bhbih" +
+	"bibis" +
+	"bmbam" +
+	"bnben" +
+	"bobod" +
+	"brbre" +
+	"bsbos" +
+	"cacat" +
+	"ceche" +
+	"chcha" +
+	"cocos" +
+	"crcre" +
+	"csces" +
+	"cuchu" +
+	"cvchv" +
+	"cycym" +
+	"dadan" +
+	"dedeu" +
+	"dvdiv" +
+	"dzdzo" +
+	"eeewe" +
+	"elell" +
+	"eneng" +
+	"eoepo" +
+	"esspa" +
+	"etest" +
+	"eueus" +
+	"fafas" +
+	"ffful" +
+	"fifin" +
+	"fjfij" +
+	"fofao" +
+	"frfra" +
+	"fyfry" +
+	"gagle" +
+	"gdgla" +
+	"glglg" +
+	"gngrn" +
+	"guguj" +
+	"gvglv" +
+	"hahau" +
+	"heheb" +
+	"hihin" +
+	"hohmo" +
+	"hrhrv" +
+	"hthat" +
+	"huhun" +
+	"hyhye" +
+	"hzher" +
+	"iaina" +
+	"idind" +
+	"ieile" +
+	"igibo" +
+	"iiiii" +
+	"ikipk" +
+	"ioido" +
+	"isisl" +
+	"itita" +
+	"iuiku" +
+	"jajpn" +
+	"jvjav" +
+	"kakat" +
+	"kgkon" +
+	"kikik" +
+	"kjkua" +
+	"kkkaz" +
+	"klkal" +
+	"kmkhm" +
+	"knkan" +
+	"kokor" +
+	"krkau" +
+	"kskas" +
+	"kukur" +
+	"kvkom" +
+	"kwcor" +
+	"kykir" +
+	"lalat" +
+	"lbltz" +
+	"lglug" +
+	"lilim" +
+	"lnlin" +
+	"lolao" +
+	"ltlit" +
+	"lulub" +
+	"lvlav" +
+	"mgmlg" +
+	"mhmah" +
+	"mimri" +
+	"mkmkd" +
+	"mlmal" +
+	"mnmon" +
+	"mrmar" +
+	"msmsa" +
+	"mtmlt" +
+	"mymya" +
+	"nanau" +
+	"nbnob" +
+	"ndnde" +
+	"nenep" +
+	"ngndo" +
+	"nlnld" +
+	"nnnno" +
+	"nonor" +
+	"nrnbl" +
+	"nvnav" +
+	"nynya" +
+	"ococi" +
+	"ojoji" +
+	"omorm" +
+	"orori" +
+	"ososs" +
+	"papan" +
+	"pipli" +
+	"plpol" +
+	"pspus" +
+	"ptpor" +
+	"quque" +
+	"rmroh" +
+	"rnrun" +
+	"roron" +
+	"rurus" +
+	"rwkin" +
+	"sasan" +
+	"scsrd" +
+	"sdsnd" +
+	"sesme" +
+	"sgsag" +
+	"sisin" +
+	"skslk" +
+	"slslv" +
+	"smsmo" +
+	"snsna" +
+	"sosom" +
+	"sqsqi" +
+	"srsrp" +
+	"ssssw" +
+	"stsot" +
+	"susun" +
+	"svswe" +
+	"swswa" +
+	"tatam" +
+	"tetel" +
+	"tgtgk" +
+	"ththa" +
+	"titir" +
+	"tktuk" +
+	"tltgl" +
+	"tntsn" +
+	"toton" +
+	"trtur" +
+	"tstso" +
+	"tttat" +
+	"twtwi" +
+	"tytah" +
+	"uguig" +
+	"ukukr" +
+	"ururd" +
+	"uzuzb" +
+	"veven" +
+	"vivie" +
+	"vovol" +
+	"wawln" +
+	"wowol" +
+	"xhxho" +
+	"yiyid" +
+	"yoyor" +
+	"zazha" +
+	"zhzho" +
+	"zuzul"
 
-// List of ISO 639 set 2 language codes
-var languageSet2 = map[string]string{
-	"aar": "aar",
-	"abk": "abk",
-	"ace": "ace",
-	"ach": "ach",
-	"ada": "ada",
-	"ady": "ady",
-	"afa": "afa",
-	"afh": "afh",
-	"afr": "afr",
-	"ain": "ain",
-	"aka": "aka",
-	"akk": "akk",
-	"alb": "sqi",
-	"ale": "ale",
-	"alg": "alg",
-	"alt": "alt",
-	"amh": "amh",
-	"ang": "ang",
-	"anp": "anp",
-	"apa": "apa",
-	"ara": "ara",
-	"arc": "arc",
-	"arg": "arg",
-	"arm": "hye",
-	"arn": "arn",
-	"arp": "arp",
-	"art": "art",
-	"arw": "arw",
-	"asm": "asm",
-	"ast": "ast",
-	"ath": "ath",
-	"aus": "aus",
-	"ava": "ava",
-	"ave": "ave",
-	"awa": "awa",
-	"aym": "aym",
-	"aze": "aze",
-	"bad": "bad",
-	"bai": "bai",
-	"bak": "bak",
-	"bal": "bal",
-	"bam": "bam",
-	"ban": "ban",
-	"baq": "eus",
-	"bas": "bas",
-	"bat": "bat",
-	"bej": "bej",
-	"bel": "bel",
-	"bem": "bem",
-	"ben": "ben",
-	"ber": "ber",
-	"bho": "bho",
-	"bih": "bih",
-	"bik": "bik",
-	"bin": "bin",
-	"bis": "bis",
-	"bla": "bla",
-	"bnt": "bnt",
-	"bod": "bod",
-	"bos": "bos",
-	"bra": "bra",
-	"bre": "bre",
-	"btk": "btk",
-	"bua": "bua",
-	"bug": "bug",
-	"bul": "bul",
-	"bur": "mya",
-	"byn": "byn",
-	"cad": "cad",
-	"cai": "cai",
-	"car": "car",
-	"cat": "cat",
-	"cau": "cau",
-	"ceb": "ceb",
-	"cel": "cel",
-	"ces": "ces",
-	"cha": "cha",
-	"chb": "chb",
-	"che": "che",
-	"chg": "chg",
-	"chi": "zho",
-	"chk": "chk",
-	"chm": "chm",
-	"chn": "chn",
-	"cho": "cho",
-	"chp": "chp",
-	"chr": "chr",
-	"chu": "chu",
-	"chv": "chv",
-	"chy": "chy",
-	"cmc": "cmc",
-	"cop": "cop",
-	"cor": "cor",
-	"cos": "cos",
-	"cpe": "cpe",
-	"cpf": "cpf",
-	"cpp": "cpp",
-	"cre": "cre",
-	"crh": "crh",
-	"crp": "crp",
-	"csb": "csb",
-	"cus": "cus",
-	"cym": "cym",
-	"cze": "ces",
-	"dak": "dak",
-	"dan": "dan",
-	"dar": "dar",
-	"day": "day",
-	"del": "del",
-	"den": "den",
-	"deu": "deu",
-	"dgr": "dgr",
-	"din": "din",
-	"div": "div",
-	"doi": "doi",
-	"dra": "dra",
-	"dsb": "dsb",
-	"dua": "dua",
-	"dum": "dum",
-	"dut": "nld",
-	"dyu": "dyu",
-	"dzo": "dzo",
-	"efi": "efi",
-	"egy": "egy",
-	"eka": "eka",
-	"ell": "ell",
-	"elx": "elx",
-	"eng": "eng",
-	"enm": "enm",
-	"epo": "epo",
-	"est": "est",
-	"eus": "eus",
-	"ewe": "ewe",
-	"ewo": "ewo",
-	"fan": "fan",
-	"fao": "fao",
-	"fas": "fas",
-	"fat": "fat",
-	"fij": "fij",
-	"fil": "fil",
-	"fin": "fin",
-	"fiu": "fiu",
-	"fon": "fon",
-	"fra": "fra",
-	"fre": "fra",
-	"frm": "frm",
-	"fro": "fro",
-	"frr": "frr",
-	"frs": "frs",
-	"fry": "fry",
-	"ful": "ful",
-	"fur": "fur",
-	"gaa": "gaa",
-	"gay": "gay",
-	"gba": "gba",
-	"gem": "gem",
-	"geo": "kat",
-	"ger": "deu",
-	"gez": "gez",
-	"gil": "gil",
-	"gla": "gla",
-	"gle": "gle",
-	"glg": "glg",
-	"glv": "glv",
-	"gmh": "gmh",
-	"goh": "goh",
-	"gon": "gon",
-	"gor": "gor",
-	"got": "got",
-	"grb": "grb",
-	"grc": "grc",
-	"gre": "ell",
-	"grn": "grn",
-	"gsw": "gsw",
-	"guj": "guj",
-	"gwi": "gwi",
-	"hai": "hai",
-	"hat": "hat",
-	"hau": "hau",
-	"haw": "haw",
-	"heb": "heb",
-	"her": "her",
-	"hil": "hil",
-	"him": "him",
-	"hin": "hin",
-	"hit": "hit",
-	"hmn": "hmn",
-	"hmo": "hmo",
-	"hrv": "hrv",
-	"hsb": "hsb",
-	"hun": "hun",
-	"hup": "hup",
-	"hye": "hye",
-	"iba": "iba",
-	"ibo": "ibo",
-	"ice": "isl",
-	"ido": "ido",
-	"iii": "iii",
-	"ijo": "ijo",
-	"iku": "iku",
-	"ile": "ile",
-	"ilo": "ilo",
-	"ina": "ina",
-	"inc": "inc",
-	"ind": "ind",
-	"ine": "ine",
-	"inh": "inh",
-	"ipk": "ipk",
-	"ira": "ira",
-	"iro": "iro",
-	"isl": "isl",
-	"ita": "ita",
-	"jav": "jav",
-	"jbo": "jbo",
-	"jpn": "jpn",
-	"jpr": "jpr",
-	"jrb": "jrb",
-	"kaa": "kaa",
-	"kab": "kab",
-	"kac": "kac",
-	"kal": "kal",
-	"kam": "kam",
-	"kan": "kan",
-	"kar": "kar",
-	"kas": "kas",
-	"kat": "kat",
-	"kau": "kau",
-	"kaw": "kaw",
-	"kaz": "kaz",
-	"kbd": "kbd",
-	"kha": "kha",
-	"khi": "khi",
-	"khm": "khm",
-	"kho": "kho",
-	"kik": "kik",
-	"kin": "kin",
-	"kir": "kir",
-	"kmb": "kmb",
-	"kok": "kok",
-	"kom": "kom",
-	"kon": "kon",
-	"kor": "kor",
-	"kos": "kos",
-	"kpe": "kpe",
-	"krc": "krc",
-	"krl": "krl",
-	"kro": "kro",
-	"kru": "kru",
-	"kua": "kua",
-	"kum": "kum",
-	"kur": "kur",
-	"kut": "kut",
-	"lad": "lad",
-	"lah": "lah",
-	"lam": "lam",
-	"lao": "lao",
-	"lat": "lat",
-	"lav": "lav",
-	"lez": "lez",
-	"lim": "lim",
-	"lin": "lin",
-	"lit": "lit",
-	"lol": "lol",
-	"loz": "loz",
-	"ltz": "ltz",
-	"lua": "lua",
-	"lub": "lub",
-	"lug": "lug",
-	"lui": "lui",
-	"lun": "lun",
-	"luo": "luo",
-	"lus": "lus",
-	"mac": "mkd",
-	"mad": "mad",
-	"mag": "mag",
-	"mah": "mah",
-	"mai": "mai",
-	"mak": "mak",
-	"mal": "mal",
-	"man": "man",
-	"mao": "mri",
-	"map": "map",
-	"mar": "mar",
-	"mas": "mas",
-	"may": "msa",
-	"mdf": "mdf",
-	"mdr": "mdr",
-	"men": "men",
-	"mga": "mga",
-	"mic": "mic",
-	"min": "min",
-	"mis": "mis",
-	"mkd": "mkd",
-	"mkh": "mkh",
-	"mlg": "mlg",
-	"mlt": "mlt",
-	"mnc": "mnc",
-	"mni": "mni",
-	"mno": "mno",
-	"moh": "moh",
-	"mon": "mon",
-	"mos": "mos",
-	"mri": "mri",
-	"msa": "msa",
-	"mul": "mul",
-	"mun": "mun",
-	"mus": "mus",
-	"mwl": "mwl",
-	"mwr": "mwr",
-	"mya": "mya",
-	"myn": "myn",
-	"myv": "myv",
-	"nah": "nah",
-	"nai": "nai",
-	"nap": "nap",
-	"nau": "nau",
-	"nav": "nav",
-	"nbl": "nbl",
-	"nde": "nde",
-	"ndo": "ndo",
-	"nds": "nds",
-	"nep": "nep",
-	"new": "new",
-	"nia": "nia",
-	"nic": "nic",
-	"niu": "niu",
-	"nld": "nld",
-	"nno": "nno",
-	"nob": "nob",
-	"nog": "nog",
-	"non": "non",
-	"nor": "nor",
-	"nqo": "nqo",
-	"nso": "nso",
-	"nub": "nub",
-	"nwc": "nwc",
-	"nya": "nya",
-	"nym": "nym",
-	"nyn": "nyn",
-	"nyo": "nyo",
-	"nzi": "nzi",
-	"oci": "oci",
-	"oji": "oji",
-	"ori": "ori",
-	"orm": "orm",
-	"osa": "osa",
-	"oss": "oss",
-	"ota": "ota",
-	"oto": "oto",
-	"paa": "paa",
-	"pag": "pag",
-	"pal": "pal",
-	"pam": "pam",
-	"pan": "pan",
-	"pap": "pap",
-	"pau": "pau",
-	"peo": "peo",
-	"per": "fas",
-	"phi": "phi",
-	"phn": "phn",
-	"pli": "pli",
-	"pol": "pol",
-	"pon": "pon",
-	"por": "por",
-	"pra": "pra",
-	"pro": "pro",
-	"pus": "pus",
-	"que": "que",
-	"raj": "raj",
-	"rap": "rap",
-	"rar": "rar",
-	"roa": "roa",
-	"roh": "roh",
-	"rom": "rom",
-	"ron": "ron",
-	"rum": "ron",
-	"run": "run",
-	"rup": "rup",
-	"rus": "rus",
-	"sad": "sad",
-	"sag": "sag",
-	"sah": "sah",
-	"sai": "sai",
-	"sal": "sal",
-	"sam": "sam",
-	"san": "san",
-	"sas": "sas",
-	"sat": "sat",
-	"scn": "scn",
-	"sco": "sco",
-	"sel": "sel",
-	"sem": "sem",
-	"sga": "sga",
-	"sgn": "sgn",
-	"shn": "shn",
-	"sid": "sid",
-	"sin": "sin",
-	"sio": "sio",
-	"sit": "sit",
-	"sla": "sla",
-	"slk": "slk",
-	"slo": "slk",
-	"slv": "slv",
-	"sma": "sma",
-	"sme": "sme",
-	"smi": "smi",
-	"smj": "smj",
-	"smn": "smn",
-	"smo": "smo",
-	"sms": "sms",
-	"sna": "sna",
-	"snd": "snd",
-	"snk": "snk",
-	"sog": "sog",
-	"som": "som",
-	"son": "son",
-	"sot": "sot",
-	"spa": "spa",
-	"sqi": "sqi",
-	"srd": "srd",
-	"srn": "srn",
-	"srp": "srp",
-	"srr": "srr",
-	"ssa": "ssa",
-	"ssw": "ssw",
-	"suk": "suk",
-	"sun": "sun",
-	"sus": "sus",
-	"sux": "sux",
-	"swa": "swa",
-	"swe": "swe",
-	"syc": "syc",
-	"syr": "syr",
-	"tah": "tah",
-	"tai": "tai",
-	"tam": "tam",
-	"tat": "tat",
-	"tel": "tel",
-	"tem": "tem",
-	"ter": "ter",
-	"tet": "tet",
-	"tgk": "tgk",
-	"tgl": "tgl",
-	"tha": "tha",
-	"tib": "bod",
-	"tig": "tig",
-	"tir": "tir",
-	"tiv": "tiv",
-	"tkl": "tkl",
-	"tlh": "tlh",
-	"tli": "tli",
-	"tmh": "tmh",
-	"tog": "tog",
-	"ton": "ton",
-	"tpi": "tpi",
-	"tsi": "tsi",
-	"tsn": "tsn",
-	"tso": "tso",
-	"tuk": "tuk",
-	"tum": "tum",
-	"tup": "tup",
-	"tur": "tur",
-	"tut": "tut",
-	"tvl": "tvl",
-	"twi": "twi",
-	"tyv": "tyv",
-	"udm": "udm",
-	"uga": "uga",
-	"uig": "uig",
-	"ukr": "ukr",
-	"umb": "umb",
-	"und": "und",
-	"urd": "urd",
-	"uzb": "uzb",
-	"vai": "vai",
-	"ven": "ven",
-	"vie": "vie",
-	"vol": "vol",
-	"vot": "vot",
-	"wak": "wak",
-	"wal": "wal",
-	"war": "war",
-	"was": "was",
-	"wel": "cym",
-	"wen": "wen",
-	"wln": "wln",
-	"wol": "wol",
-	"xal": "xal",
-	"xho": "xho",
-	"yao": "yao",
-	"yap": "yap",
-	"yid": "yid",
-	"yor": "yor",
-	"ypk": "ypk",
-	"zap": "zap",
-	"zbl": "zbl",
-	"zen": "zen",
-	"zgh": "zgh",
-	"zha": "zha",
-	"zho": "zho",
-	"znd": "znd",
-	"zul": "zul",
-	"zun": "zun",
-	"zxx": "zxx",
-	"zza": "zza",
-}
+// List of ISO 639 set 2 language codes, stored as sorted records of the three-letter code followed by
+// the terminology code
+const languageSet2 = "" +
+	"aaraar" +
+	"abkabk" +
+	"aceace" +
+	"achach" +
+	"adaada" +
+	"adyady" +
+	"afaafa" +
+	"afhafh" +
+	"afrafr" +
+	"ainain" +
+	"akaaka" +
+	"akkakk" +
+	"albsqi" +
+	"aleale" +
+	"algalg" +
+	"altalt" +
+	"amhamh" +
+	"angang" +
+	"anpanp" +
+	"apaapa" +
+	"araara" +
+	"arcarc" +
+	"argarg" +
+	"armhye" +
+	"arnarn" +
+	"arparp" +
+	"artart" +
+	"arwarw" +
+	"asmasm" +
+	"astast" +
+	"athath" +
+	"ausaus" +
+	"avaava" +
+	"aveave" +
+	"awaawa" +
+	"aymaym" +
+	"azeaze" +
+	"badbad" +
+	"baibai" +
+	"bakbak" +
+	"balbal" +
+	"bambam" +
+	"banban" +
+	"baqeus" +
+	"basbas" +
+	"batbat" +
+	"bejbej" +
+	"belbel" +
+	"bembem" +
+	"benben" +
+	"berber" +
+	"bhobho" +
+	"bihbih" +
+	"bikbik" +
+	"binbin" +
+	"bisbis" +
+	"blabla" +
+	"bntbnt" +
+	"bodbod" +
+	"bosbos" +
+	"brabra" +
+	"brebre" +
+	"btkbtk" +
+	"buabua" +
+	"bugbug" +
+	"bulbul" +
+	"burmya" +
+	"bynbyn" +
+	"cadcad" +
+	"caicai" +
+	"carcar" +
+	"catcat" +
+	"caucau" +
+	"cebceb" +
+	"celcel" +
+	"cesces" +
+	"chacha" +
+	"chbchb" +
+	"cheche" +
+	"chgchg" +
+	"chizho" +
+	"chkchk" +
+	"chmchm" +
+	"chnchn" +
+	"chocho" +
+	"chpchp" +
+	"chrchr" +
+	"chuchu" +
+	"chvchv" +
+	"chychy" +
+	"cmccmc" +
+	"copcop" +
+	"corcor" +
+	"coscos" +
+	"cpecpe" +
+	"cpfcpf" +
+	"cppcpp" +
+	"crecre" +
+	"crhcrh" +
+	"crpcrp" +
+	"csbcsb" +
+	"cuscus" +
+	"cymcym" +
+	"czeces" +
+	"dakdak" +
+	"dandan" +
+	"dardar" +
+	"dayday" +
+	"deldel" +
+	"denden" +
+	"deudeu" +
+	"dgrdgr" +
+	"dindin" +
+	"divdiv" +
+	"doidoi" +
+	"dradra" +
+	"dsbdsb" +
+	"duadua" +
+	"dumdum" +
+	"dutnld" +
+	"dyudyu" +
+	"dzodzo" +
+	"efiefi" +
+	"egyegy" +
+	"ekaeka" +
+	"ellell" +
+	"elxelx" +
+	"engeng" +
+	"enmenm" +
+	"epoepo" +
+	"estest" +
+	"euseus" +
+	"eweewe" +
+	"ewoewo" +
+	"fanfan" +
+	"faofao" +
+	"fasfas" +
+	"fatfat" +
+	"fijfij" +
+	"filfil" +
+	"finfin" +
+	"fiufiu" +
+	"fonfon" +
+	"frafra" +
+	"frefra" +
+	"frmfrm" +
+	"frofro" +
+	"frrfrr" +
+	"frsfrs" +
+	"fryfry" +
+	"fulful" +
+	"furfur" +
+	"gaagaa" +
+	"gaygay" +
+	"gbagba" +
+	"gemgem" +
+	"geokat" +
+	"gerdeu" +
+	"gezgez" +
+	"gilgil" +
+	"glagla" +
+	"glegle" +
+	"glgglg" +
+	"glvglv" +
+	"gmhgmh" +
+	"gohgoh" +
+	"gongon" +
+	"gorgor" +
+	"gotgot" +
+	"grbgrb" +
+	"grcgrc" +
+	"greell" +
+	"grngrn" +
+	"gswgsw" +
+	"gujguj" +
+	"gwigwi" +
+	"haihai" +
+	"hathat" +
+	"hauhau" +
+	"hawhaw" +
+	"hebheb" +
+	"herher" +
+	"hilhil" +
+	"himhim" +
+	"hinhin" +
+	"hithit" +
+	"hmnhmn" +
+	"hmohmo" +
+	"hrvhrv" +
+	"hsbhsb" +
+	"hunhun" +
+	"huphup" +
+	"hyehye" +
+	"ibaiba" +
+	"iboibo" +
+	"iceisl" +
+	"idoido" +
+	"iiiiii" +
+	"ijoijo" +
+	"ikuiku" +
+	"ileile" +
+	"iloilo" +
+	"inaina" +
+	"incinc" +
+	"indind" +
+	"ineine" +
+	"inhinh" +
+	"ipkipk" +
+	"iraira" +
+	"iroiro" +
+	"islisl" +
+	"itaita" +
+	"javjav" +
+	"jbojbo" +
+	"jpnjpn" +
+	"jprjpr" +
+	"jrbjrb" +
+	"kaakaa" +
+	"kabkab" +
+	"kackac" +
+	"kalkal" +
+	"kamkam" +
+	"kankan" +
+	"karkar" +
+	"kaskas" +
+	"katkat" +
+	"kaukau" +
+	"kawkaw" +
+	"kazkaz" +
+	"kbdkbd" +
+	"khakha" +
+	"khikhi" +
+	"khmkhm" +
+	"khokho" +
+	"kikkik" +
+	"kinkin" +
+	"kirkir" +
+	"kmbkmb" +
+	"kokkok" +
+	"komkom" +
+	"konkon" +
+	"korkor" +
+	"koskos" +
+	"kpekpe" +
+	"krckrc" +
+	"krlkrl" +
+	"krokro" +
+	"krukru" +
+	"kuakua" +
+	"kumkum" +
+	"kurkur" +
+	"kutkut" +
+	"ladlad" +
+	"lahlah" +
+	"lamlam" +
+	"laolao" +
+	"latlat" +
+	"lavlav" +
+	"lezlez" +
+	"limlim" +
+	"linlin" +
+	"litlit" +
+	"lollol" +
+	"lozloz" +
+	"ltzltz" +
+	"lualua" +
+	"lublub" +
+	"luglug" +
+	"luilui" +
+	"lunlun" +
+	"luoluo" +
+	"luslus" +
+	"macmkd" +
+	"madmad" +
+	"magmag" +
+	"mahmah" +
+	"maimai" +
+	"makmak" +
+	"malmal" +
+	"manman" +
+	"maomri" +
+	"mapmap" +
+	"marmar" +
+	"masmas" +
+	"maymsa" +
+	"mdfmdf" +
+	"mdrmdr" +
+	"menmen" +
+	"mgamga" +
+	"micmic" +
+	"minmin" +
+	"mismis" +
+	"mkdmkd" +
+	"mkhmkh" +
+	"mlgmlg" +
+	"mltmlt" +
+	"mncmnc" +
+	"mnimni" +
+	"mnomno" +
+	"mohmoh" +
+	"monmon" +
+	"mosmos" +
+	"mrimri" +
+	"msamsa" +
+	"mulmul" +
+	"munmun" +
+	"musmus" +
+	"mwlmwl" +
+	"mwrmwr" +
+	"myamya" +
+	"mynmyn" +
+	"myvmyv" +
+	"nahnah" +
+	"nainai" +
+	"napnap" +
+	"naunau" +
+	"navnav" +
+	"nblnbl" +
+	"ndende" +
+	"ndondo" +
+	"ndsnds" +
+	"nepnep" +
+	"newnew" +
+	"niania" +
+	"nicnic" +
+	"niuniu" +
+	"nldnld" +
+	"nnonno" +
+	"nobnob" +
+	"nognog" +
+	"nonnon" +
+	"nornor" +
+	"nqonqo" +
+	"nsonso" +
+	"nubnub" +
+	"nwcnwc" +
+	"nyanya" +
+	"nymnym" +
+	"nynnyn" +
+	"nyonyo" +
+	"nzinzi" +
+	"ocioci" +
+	"ojioji" +
+	"oriori" +
+	"ormorm" +
+	"osaosa" +
+	"ossoss" +
+	"otaota" +
+	"otooto" +
+	"paapaa" +
+	"pagpag" +
+	"palpal" +
+	"pampam" +
+	"panpan" +
+	"pappap" +
+	"paupau" +
+	"peopeo" +
+	"perfas" +
+	"phiphi" +
+	"phnphn" +
+	"plipli" +
+	"polpol" +
+	"ponpon" +
+	"porpor" +
+	"prapra" +
+	"propro" +
+	"puspus" +
+	"queque" +
+	"rajraj" +
+	"raprap" +
+	"rarrar" +
+	"roaroa" +
+	"rohroh" +
+	"romrom" +
+	"ronron" +
+	"rumron" +
+	"runrun" +
+	"ruprup" +
+	"rusrus" +
+	"sadsad" +
+	"sagsag" +
+	"sahsah" +
+	"saisai" +
+	"salsal" +
+	"samsam" +
+	"sansan" +
+	"sassas" +
+	"satsat" +
+	"scnscn" +
+	"scosco" +
+	"selsel" +
+	"semsem" +
+	"sgasga" +
+	"sgnsgn" +
+	"shnshn" +
+	"sidsid" +
+	"sinsin" +
+	"siosio" +
+	"sitsit" +
+	"slasla" +
+	"slkslk" +
+	"sloslk" +
+	"slvslv" +
+	"smasma" +
+	"smesme" +
+	"smismi" +
+	"smjsmj" +
+	"smnsmn" +
+	"smosmo" +
+	"smssms" +
+	"snasna" +
+	"sndsnd" +
+	"snksnk" +
+	"sogsog" +
+	"somsom" +
+	"sonson" +
+	"sotsot" +
+	"spaspa" +
+	"sqisqi" +
+	"srdsrd" +
+	"srnsrn" +
+	"srpsrp" +
+	"srrsrr" +
+	"ssassa" +
+	"sswssw" +
+	"suksuk" +
+	"sunsun" +
+	"sussus" +
+	"suxsux" +
+	"swaswa" +
+	"sweswe" +
+	"sycsyc" +
+	"syrsyr" +
+	"tahtah" +
+	"taitai" +
+	"tamtam" +
+	"tattat" +
+	"teltel" +
+	"temtem" +
+	"terter" +
+	"tettet" +
+	"tgktgk" +
+	"tgltgl" +
+	"thatha" +
+	"tibbod" +
+	"tigtig" +
+	"tirtir" +
+	"tivtiv" +
+	"tkltkl" +
+	"tlhtlh" +
+	"tlitli" +
+	"tmhtmh" +
+	"togtog" +
+	"tonton" +
+	"tpitpi" +
+	"tsitsi" +
+	"tsntsn" +
+	"tsotso" +
+	"tuktuk" +
+	"tumtum" +
+	"tuptup" +
+	"turtur" +
+	"tuttut" +
+	"tvltvl" +
+	"twitwi" +
+	"tyvtyv" +
+	"udmudm" +
+	"ugauga" +
+	"uiguig" +
+	"ukrukr" +
+	"umbumb" +
+	"undund" +
+	"urdurd" +
+	"uzbuzb" +
+	"vaivai" +
+	"venven" +
+	"vievie" +
+	"volvol" +
+	"votvot" +
+	"wakwak" +
+	"walwal" +
+	"warwar" +
+	"waswas" +
+	"welcym" +
+	"wenwen" +
+	"wlnwln" +
+	"wolwol" +
+	"xalxal" +
+	"xhoxho" +
+	"yaoyao" +
+	"yapyap" +
+	"yidyid" +
+	"yoryor" +
+	"ypkypk" +
+	"zapzap" +
+	"zblzbl" +
+	"zenzen" +
+	"zghzgh" +
+	"zhazha" +
+	"zhozho" +
+	"zndznd" +
+	"zulzul" +
+	"zunzun" +
+	"zxxzxx" +
+	"zzazza"
 
-// List of ISO 15924 scripts
-var scripts = map[string]string{
-	"Adlm": "166",
-	"Afak": "439",
-	"Aghb": "239",
-	"Ahom": "338",
-	"Arab": "160",
-	"Aran": "161",
-	"Armi": "124",
-	"Armn": "230",
-	"Avst": "134",
-	"Bali": "360",
-	"Bamu": "435",
-	"Bass": "259",
-	"Batk": "365",
-	"Beng": "325",
-	"Berf": "258",
-	"Bhks": "334",
-	"Blis": "550",
-	"Bopo": "285",
-	"Brah": "300",
-	"Brai": "570",
-	"Bugi": "367",
-	"Buhd": "372",
-	"Cakm": "349",
-	"Cans": "440",
-	"Cari": "201",
-	"Cham": "358",
-	"Cher": "445",
-	"Chis": "298",
-	"Chrs": "109",
-	"Cirt": "291",
-	"Copt": "204",
-	"Cpmn": "402",
-	"Cprt": "403",
-	"Cyrl": "220",
-	"Cyrs": "221",
-	"Deva": "315",
-	"Diak": "342",
-	"Dogr": "328",
-	"Dsrt": "250",
-	"Dupl": "755",
-	"Egyd": "070",
-	"Egyh": "060",
-	"Egyp": "050",
-	"Elba": "226",
-	"Elym": "128",
-	"Ethi": "430",
-	"Gara": "164",
-	"Geok": "241",
-	"Geor": "240",
-	"Glag": "225",
-	"Gong": "312",
-	"Gonm": "313",
-	"Goth": "206",
-	"Gran": "343",
-	"Grek": "200",
-	"Gujr": "320",
-	"Gukh": "397",
-	"Guru": "310",
-	"Hanb": "503",
-	"Hang": "286",
-	"Hani": "500",
-	"Hano": "371",
-	"Hans": "501",
-	"Hant": "502",
-	"Hatr": "127",
-	"Hebr": "125",
-	"Hira": "410",
-	"Hluw": "080",
-	"Hmng": "450",
-	"Hmnp": "451",
-	"Hrkt": "412",
-	"Hung": "176",
-	"Inds": "610",
-	"Ital": "210",
-	"Jamo": "284",
-	"Java": "361",
-	"Jpan": "413",
-	"Jurc": "510",
-	"Kali": "357",
-	"Kana": "411",
-	"Kawi": "368",
-	"Khar": "305",
-	"Khmr": "355",
-	"Khoj": "322",
-	"Kitl": "505",
-	"Kits": "288",
-	"Knda": "345",
-	"Kore": "287",
-	"Kpel": "436",
-	"Krai": "396",
-	"Kthi": "317",
-	"Lana": "351",
-	"Laoo": "356",
-	"Latf": "217",
-	"Latg": "216",
-	"Latn": "215",
-	"Leke": "364",
-	"Lepc": "335",
-	"Limb": "336",
-	"Lina": "400",
-	"Linb": "401",
-	"Lisu": "399",
-	"Loma": "437",
-	"Lyci": "202",
-	"Lydi": "116",
-	"Mahj": "314",
-	"Maka": "366",
-	"Mand": "140",
-	"Mani": "139",
-	"Marc": "332",
-	"Maya": "90",
-	"Medf": "265",
-	"Mend": "438",
-	"Merc": "101",
-	"Mero": "100",
-	"Mlym": "347",
-	"Modi": "324",
-	"Mong": "145",
-	"Moon": "218",
-	"Mroo": "264",
-	"Mtei": "337",
-	"Mult": "323",
-	"Mymr": "350",
-	"Nagm": "295",
-	"Nand": "311",
-	"Narb": "106",
-	"Nbat": "159",
-	"Newa": "333",
-	"Nkdb": "085",
-	"Nkgb": "420",
-	"Nkoo": "165",
-	"Nshu": "499",
-	"Ogam": "212",
-	"Olck": "261",
-	"Onao": "296",
-	"Orkh": "175",
-	"Orya": "327",
-	"Osge": "219",
-	"Osma": "260",
-	"Ougr": "143",
-	"Palm": "126",
-	"Pauc": "263",
-	"Pcun": "015",
-	"Pelm": "016",
-	"Perm": "227",
-	"Phag": "331",
-	"Phli": "131",
-	"Phlp": "132",
-	"Phlv": "133",
-	"Phnx": "115",
-	"Piqd": "293",
-	"Plrd": "282",
-	"Prti": "130",
-	"Psin": "103",
-	"Ranj": "303",
-	"Rjng": "363",
-	"Rohg": "167",
-	"Roro": "620",
-	"Runr": "211",
-	"Samr": "123",
-	"Sara": "292",
-	"Sarb": "105",
-	"Saur": "344",
-	"Sgnw": "095",
-	"Shaw": "281",
-	"Shrd": "319",
-	"Shui": "530",
-	"Sidd": "302",
-	"Sidt": "180",
-	"Sind": "318",
-	"Sinh": "348",
-	"Sogd": "141",
-	"Sogo": "142",
-	"Sora": "398",
-	"Soyo": "329",
-	"Sund": "362",
-	"Sunu": "274",
-	"Sylo": "316",
-	"Syrc": "135",
-	"Syre": "138",
-	"Syrj": "137",
-	"Syrn": "136",
-	"Tagb": "373",
-	"Takr": "321",
-	"Tale": "353",
-	"Talu": "354",
-	"Taml": "346",
-	"Tang": "520",
-	"Tavt": "359",
-	"Tayo": "380",
-	"Telu": "340",
-	"Teng": "290",
-	"Tfng": "120",
-	"Tglg": "370",
-	"Thaa": "170",
-	"Thai": "352",
-	"Tibt": "330",
-	"Tirh": "326",
-	"Tnsa": "275",
-	"Todr": "229",
-	"Tols": "299",
-	"Toto": "294",
-	"Tutg": "341",
-	"Ugar": "040",
-	"Vaii": "470",
-	"Visp": "280",
-	"Vith": "228",
-	"Wara": "262",
-	"Wcho": "283",
-	"Wole": "480",
-	"Xpeo": "030",
-	"Xsux": "020",
-	"Yezi": "192",
-	"Yiii": "460",
-	"Zanb": "339",
-	"Zinh": "994",
-	"Zmth": "995",
-	"Zsye": "993",
-	"Zsym": "996",
-	"Zxxx": "997",
-	"Zyyy": "998",
-	"Zzzz": "999",
-}
+// List of the ISO 639 set 2 terminology codes of the languages with an ISO 639 set 1 code, stored as sorted records of
+// the terminology code followed by the two-letter code
+const languageSet1Terminology = "" +
+	"aaraa" +
+	"abkab" +
+	"afraf" +
+	"akaak" +
+	"amham" +
+	"araar" +
+	"argan" +
+	"asmas" +
+	"avaav" +
+	"aveae" +
+	"aymay" +
+	"azeaz" +
+	"bakba" +
+	"bambm" +
+	"belbe" +
+	"benbn" +
+	"bihbh" +
+	"bisbi" +
+	"bodbo" +
+	"bosbs" +
+	"brebr" +
+	"bulbg" +
+	"catca" +
+	"cescs" +
+	"chach" +
+	"chece" +
+	"chucu" +
+	"chvcv" +
+	"corkw" +
+	"cosco" +
+	"crecr" +
+	"cymcy" +
+	"danda" +
+	"deude" +
+	"divdv" +
+	"dzodz" +
+	"ellel" +
+	"engen" +
+	"epoeo" +
+	"estet" +
+	"euseu" +
+	"eweee" +
+	"faofo" +
+	"fasfa" +
+	"fijfj" +
+	"finfi" +
+	"frafr" +
+	"fryfy" +
+	"fulff" +
+	"glagd" +
+	"glega" +
+	"glggl" +
+	"glvgv" +
+	"grngn" +
+	"gujgu" +
+	"hatht" +
+	"hauha" +
+	"hebhe" +
+	"herhz" +
+	"hinhi" +
+	"hmoho" +
+	"hrvhr" +
+	"hunhu" +
+	"hyehy" +
+	"iboig" +
+	"idoio" +
+	"iiiii" +
+	"ikuiu" +
+	"ileie" +
+	"inaia" +
+	"indid" +
+	"ipkik" +
+	"islis" +
+	"itait" +
+	"javjv" +
+	"jpnja" +
+	"kalkl" +
+	"kankn" +
+	"kasks" +
+	"katka" +
+	"kaukr" +
+	"kazkk" +
+	"khmkm" +
+	"kikki" +
+	"kinrw" +
+	"kirky" +
+	"komkv" +
+	"konkg" +
+	"korko" +
+	"kuakj" +
+	"kurku" +
+	"laolo" +
+	"latla" +
+	"lavlv" +
+	"limli" +
+	"linln" +
+	"litlt" +
+	"ltzlb" +
+	"lublu" +
+	"luglg" +
+	"mahmh" +
+	"malml" +
+	"marmr" +
+	"mkdmk" +
+	"mlgmg" +
+	"mltmt" +
+	"monmn" +
+	"mrimi" +
+	"msams" +
+	"myamy" +
+	"nauna" +
+	"navnv" +
+	"nblnr" +
+	"ndend" +
+	"ndong" +
+	"nepne" +
+	"nldnl" +
+	"nnonn" +
+	"nobnb" +
+	"norno" +
+	"nyany" +
+	"ocioc" +
+	"ojioj" +
+	"orior" +
+	"ormom" +
+	"ossos" +
+	"panpa" +
+	"plipi" +
+	"polpl" +
+	"porpt" +
+	"pusps" +
+	"quequ" +
+	"rohrm" +
+	"ronro" +
+	"runrn" +
+	"rusru" +
+	"sagsg" +
+	"sansa" +
+	"sinsi" +
+	"slksk" +
+	"slvsl" +
+	"smese" +
+	"smosm" +
+	"snasn" +
+	"sndsd" +
+	"somso" +
+	"sotst" +
+	"spaes" +
+	"sqisq" +
+	"srdsc" +
+	"srpsr" +
+	"sswss" +
+	"sunsu" +
+	"swasw" +
+	"swesv" +
+	"tahty" +
+	"tamta" +
+	"tattt" +
+	"telte" +
+	"tgktg" +
+	"tgltl" +
+	"thath" +
+	"tirti" +
+	"tonto" +
+	"tsntn" +
+	"tsots" +
+	"tuktk" +
+	"turtr" +
+	"twitw" +
+	"uigug" +
+	"ukruk" +
+	"urdur" +
+	"uzbuz" +
+	"venve" +
+	"vievi" +
+	"volvo" +
+	"wlnwa" +
+	"wolwo" +
+	"xhoxh" +
+	"yidyi" +
+	"yoryo" +
+	"zhaza" +
+	"zhozh" +
+	"zulzu"
 
-// List of ISO 3166-1 countries
-var countryCodes = map[string]string{
-	"AD": "020",
-	"AE": "784",
-	"AF": "004",
-	"AG": "028",
-	"AI": "660",
-	"AL": "008",
-	"AM": "051",
-	"AO": "024",
-	"AQ": "010",
-	"AR": "032",
-	"AS": "016",
-	"AT": "040",
-	"AU": "036",
-	"AW": "533",
-	"AX": "248",
-	"AZ": "031",
-	"BA": "070",
-	"BB": "052",
-	"BD": "050",
-	"BE": "056",
-	"BF": "854",
-	"BG": "100",
-	"BH": "048",
-	"BI": "108",
-	"BJ": "204",
-	"BL": "652",
-	"BM": "060",
-	"BN": "096",
-	"BO": "068",
-	"BQ": "535",
-	"BR": "076",
-	"BS": "044",
-	"BT": "064",
-	"BV": "074",
-	"BW": "072",
-	"BY": "112",
-	"BZ": "084",
-	"CA": "124",
-	"CC": "166",
-	"CD": "180",
-	"CF": "140",
-	"CG": "178",
-	"CH": "756",
-	"CI": "384",
-	"CK": "184",
-	"CL": "152",
-	"CM": "120",
-	"CN": "156",
-	"CO": "170",
-	"CR": "188",
-	"CU": "192",
-	"CV": "132",
-	"CW": "531",
-	"CX": "162",
-	"CY": "196",
-	"CZ": "203",
-	"DE": "276",
-	"DJ": "262",
-	"DK": "208",
-	"DM": "212",
-	"DO": "214",
-	"DZ": "012",
-	"EC": "218",
-	"EE": "233",
-	"EG": "818",
-	"EH": "732",
-	"ER": "232",
-	"ES": "724",
-	"ET": "231",
-	"FI": "246",
-	"FJ": "242",
-	"FK": "238",
-	"FM": "583",
-	"FO": "234",
-	"FR": "250",
-	"GA": "266",
-	"GB": "826",
-	"GD": "308",
-	"GE": "268",
-	"GF": "254",
-	"GG": "831",
-	"GH": "288",
-	"GI": "292",
-	"GL": "304",
-	"GM": "270",
-	"GN": "324",
-	"GP": "312",
-	"GQ": "226",
-	"GR": "300",
-	"GS": "239",
-	"GT": "320",
-	"GU": "316",
-	"GW": "624",
-	"GY": "328",
-	"HK": "344",
-	"HM": "334",
-	"HN": "340",
-	"HR": "191",
-	"HT": "332",
-	"HU": "348",
-	"ID": "360",
-	"IE": "372",
-	"IL": "376",
-	"IM": "833",
-	"IN": "356",
-	"IO": "086",
-	"IQ": "368",
-	"IR": "364",
-	"IS": "352",
-	"IT": "380",
-	"JE": "832",
-	"JM": "388",
-	"JO": "400",
-	"JP": "392",
-	"KE": "404",
-	"KG": "417",
-	"KH": "116",
-	"KI": "296",
-	"KM": "174",
-	"KN": "659",
-	"KP": "408",
-	"KR": "410",
-	"KW": "414",
-	"KY": "136",
-	"KZ": "398",
-	"LA": "418",
-	"LB": "422",
-	"LC": "662",
-	"LI": "438",
-	"LK": "144",
-	"LR": "430",
-	"LS": "426",
-	"LT": "440",
-	"LU": "442",
-	"LV": "428",
-	"LY": "434",
-	"MA": "504",
-	"MC": "492",
-	"MD": "498",
-	"ME": "499",
-	"MF": "663",
-	"MG": "450",
-	"MH": "584",
-	"MK": "807",
-	"ML": "466",
-	"MM": "104",
-	"MN": "496",
-	"MO": "446",
-	"MP": "580",
-	"MQ": "474",
-	"MR": "478",
-	"MS": "500",
-	"MT": "470",
-	"MU": "480",
-	"MV": "462",
-	"MW": "454",
-	"MX": "484",
-	"MY": "458",
-	"MZ": "508",
-	"NA": "516",
-	"NC": "540",
-	"NE": "562",
-	"NF": "574",
-	"NG": "566",
-	"NI": "558",
-	"NL": "528",
-	"NO": "578",
-	"NP": "524",
-	"NR": "520",
-	"NU": "570",
-	"NZ": "554",
-	"OM": "512",
-	"PA": "591",
-	"PE": "604",
-	"PF": "258",
-	"PG": "598",
-	"PH": "608",
-	"PK": "586",
-	"PL": "616",
-	"PM": "666",
-	"PN": "612",
-	"PR": "630",
-	"PS": "275",
-	"PT": "620",
-	"PW": "585",
-	"PY": "600",
-	"QA": "634",
-	"RE": "638",
-	"RO": "642",
-	"RS": "688",
-	"RU": "643",
-	"RW": "646",
-	"SA": "682",
-	"SB": "090",
-	"SC": "690",
-	"SD": "729",
-	"SE": "752",
-	"SG": "702",
-	"SH": "654",
-	"SI": "705",
-	"SJ": "744",
-	"SK": "703",
-	"SL": "694",
-	"SM": "674",
-	"SN": "686",
-	"SO": "706",
-	"SR": "740",
-	"SS": "728",
-	"ST": "678",
-	"SV": "222",
-	"SX": "534",
-	"SY": "760",
-	"SZ": "748",
-	"TC": "796",
-	"TD": "148",
-	"TF": "260",
-	"TG": "768",
-	"TH": "764",
-	"TJ": "762",
-	"TK": "772",
-	"TL": "626",
-	"TM": "795",
-	"TN": "788",
-	"TO": "776",
-	"TR": "792",
-	"TT": "780",
-	"TV": "798",
-	"TW": "158",
-	"TZ": "834",
-	"UA": "804",
-	"UG": "800",
-	"UM": "581",
-	"US": "840",
-	"UY": "858",
-	"UZ": "860",
-	"VA": "336",
-	"VC": "670",
-	"VE": "862",
-	"VG": "092",
-	"VI": "850",
-	"VN": "704",
-	"VU": "548",
-	"WF": "876",
-	"WS": "882",
-	"YE": "887",
-	"YT": "175",
-	"ZA": "710",
-	"ZM": "894",
-	"ZW": "716",
-}
+// List of ISO 15924 scripts, stored as sorted records of the four-letter code followed by the numeric code
+const scripts = "" +
+	"Adlm166" +
+	"Afak439" +
+	"Aghb239" +
+	"Ahom338" +
+	"Arab160" +
+	"Aran161" +
+	"Armi124" +
+	"Armn230" +
+	"Avst134" +
+	"Bali360" +
+	"Bamu435" +
+	"Bass259" +
+	"Batk365" +
+	"Beng325" +
+	"Berf258" +
+	"Bhks334" +
+	"Blis550" +
+	"Bopo285" +
+	"Brah300" +
+	"Brai570" +
+	"Bugi367" +
+	"Buhd372" +
+	"Cakm349" +
+	"Cans440" +
+	"Cari201" +
+	"Cham358" +
+	"Cher445" +
+	"Chis298" +
+	"Chrs109" +
+	"Cirt291" +
+	"Copt204" +
+	"Cpmn402" +
+	"Cprt403" +
+	"Cyrl220" +
+	"Cyrs221" +
+	"Deva315" +
+	"Diak342" +
+	"Dogr328" +
+	"Dsrt250" +
+	"Dupl755" +
+	"Egyd070" +
+	"Egyh060" +
+	"Egyp050" +
+	"Elba226" +
+	"Elym128" +
+	"Ethi430" +
+	"Gara164" +
+	"Geok241" +
+	"Geor240" +
+	"Glag225" +
+	"Gong312" +
+	"Gonm313" +
+	"Goth206" +
+	"Gran343" +
+	"Grek200" +
+	"Gujr320" +
+	"Gukh397" +
+	"Guru310" +
+	"Hanb503" +
+	"Hang286" +
+	"Hani500" +
+	"Hano371" +
+	"Hans501" +
+	"Hant502" +
+	"Hatr127" +
+	"Hebr125" +
+	"Hira410" +
+	"Hluw080" +
+	"Hmng450" +
+	"Hmnp451" +
+	"Hrkt412" +
+	"Hung176" +
+	"Inds610" +
+	"Ital210" +
+	"Jamo284" +
+	"Java361" +
+	"Jpan413" +
+	"Jurc510" +
+	"Kali357" +
+	"Kana411" +
+	"Kawi368" +
+	"Khar305" +
+	"Khmr355" +
+	"Khoj322" +
+	"Kitl505" +
+	"Kits288" +
+	"Knda345" +
+	"Kore287" +
+	"Kpel436" +
+	"Krai396" +
+	"Kthi317" +
+	"Lana351" +
+	"Laoo356" +
+	"Latf217" +
+	"Latg216" +
+	"Latn215" +
+	"Leke364" +
+	"Lepc335" +
+	"Limb336" +
+	"Lina400" +
+	"Linb401" +
+	"Lisu399" +
+	"Loma437" +
+	"Lyci202" +
+	"Lydi116" +
+	"Mahj314" +
+	"Maka366" +
+	"Mand140" +
+	"Mani139" +
+	"Marc332" +
+	"Maya090" +
+	"Medf265" +
+	"Mend438" +
+	"Merc101" +
+	"Mero100" +
+	"Mlym347" +
+	"Modi324" +
+	"Mong145" +
+	"Moon218" +
+	"Mroo264" +
+	"Mtei337" +
+	"Mult323" +
+	"Mymr350" +
+	"Nagm295" +
+	"Nand311" +
+	"Narb106" +
+	"Nbat159" +
+	"Newa333" +
+	"Nkdb085" +
+	"Nkgb420" +
+	"Nkoo165" +
+	"Nshu499" +
+	"Ogam212" +
+	"Olck261" +
+	"Onao296" +
+	"Orkh175" +
+	"Orya327" +
+	"Osge219" +
+	"Osma260" +
+	"Ougr143" +
+	"Palm126" +
+	"Pauc263" +
+	"Pcun015" +
+	"Pelm016" +
+	"Perm227" +
+	"Phag331" +
+	"Phli131" +
+	"Phlp132" +
+	"Phlv133" +
+	"Phnx115" +
+	"Piqd293" +
+	"Plrd282" +
+	"Prti130" +
+	"Psin103" +
+	"Ranj303" +
+	"Rjng363" +
+	"Rohg167" +
+	"Roro620" +
+	"Runr211" +
+	"Samr123" +
+	"Sara292" +
+	"Sarb105" +
+	"Saur344" +
+	"Sgnw095" +
+	"Shaw281" +
+	"Shrd319" +
+	"Shui530" +
+	"Sidd302" +
+	"Sidt180" +
+	"Sind318" +
+	"Sinh348" +
+	"Sogd141" +
+	"Sogo142" +
+	"Sora398" +
+	"Soyo329" +
+	"Sund362" +
+	"Sunu274" +
+	"Sylo316" +
+	"Syrc135" +
+	"Syre138" +
+	"Syrj137" +
+	"Syrn136" +
+	"Tagb373" +
+	"Takr321" +
+	"Tale353" +
+	"Talu354" +
+	"Taml346" +
+	"Tang520" +
+	"Tavt359" +
+	"Tayo380" +
+	"Telu340" +
+	"Teng290" +
+	"Tfng120" +
+	"Tglg370" +
+	"Thaa170" +
+	"Thai352" +
+	"Tibt330" +
+	"Tirh326" +
+	"Tnsa275" +
+	"Todr229" +
+	"Tols299" +
+	"Toto294" +
+	"Tutg341" +
+	"Ugar040" +
+	"Vaii470" +
+	"Visp280" +
+	"Vith228" +
+	"Wara262" +
+	"Wcho283" +
+	"Wole480" +
+	"Xpeo030" +
+	"Xsux020" +
+	"Yezi192" +
+	"Yiii460" +
+	"Zanb339" +
+	"Zinh994" +
+	"Zmth995" +
+	"Zsye993" +
+	"Zsym996" +
+	"Zxxx997" +
+	"Zyyy998" +
+	"Zzzz999"
 
-// List of ISO 3166-1 countries
-var countryNumbers = map[string]string{
-	"004": "AF",
-	"008": "AL",
-	"010": "AQ",
-	"012": "DZ",
-	"016": "AS",
-	"020": "AD",
-	"024": "AO",
-	"028": "AG",
-	"031": "AZ",
-	"032": "AR",
-	"036": "AU",
-	"040": "AT",
-	"044": "BS",
-	"048": "BH",
-	"050": "BD",
-	"051": "AM",
-	"052": "BB",
-	"056": "BE",
-	"060": "BM",
-	"064": "BT",
-	"068": "BO",
-	"070": "BA",
-	"072": "BW",
-	"074": "BV",
-	"076": "BR",
-	"084": "BZ",
-	"086": "IO",
-	"090": "SB",
-	"092": "VG",
-	"096": "BN",
-	"100": "BG",
-	"104": "MM",
-	"108": "BI",
-	"112": "BY",
-	"116": "KH",
-	"120": "CM",
-	"124": "CA",
-	"132": "CV",
-	"136": "KY",
-	"140": "CF",
-	"144": "LK",
-	"148": "TD",
-	"152": "CL",
-	"156": "CN",
-	"158": "TW",
-	"162": "CX",
-	"166": "CC",
-	"170": "CO",
-	"174": "KM",
-	"175": "YT",
-	"178": "CG",
-	"180": "CD",
-	"184": "CK",
-	"188": "CR",
-	"191": "HR",
-	"192": "CU",
-	"196": "CY",
-	"203": "CZ",
-	"204": "BJ",
-	"208": "DK",
-	"212": "DM",
-	"214": "DO",
-	"218": "EC",
-	"222": "SV",
-	"226": "GQ",
-	"231": "ET",
-	"232": "ER",
-	"233": "EE",
-	"234": "FO",
-	"238": "FK",
-	"239": "GS",
-	"242": "FJ",
-	"246": "FI",
-	"248": "AX",
-	"250": "FR",
-	"254": "GF",
-	"258": "PF",
-	"260": "TF",
-	"262": "DJ",
-	"266": "GA",
-	"268": "GE",
-	"270": "GM",
-	"275": "PS",
-	"276": "DE",
-	"288": "GH",
-	"292": "GI",
-	"296": "KI",
-	"300": "GR",
-	"304": "GL",
-	"308": "GD",
-	"312": "GP",
-	"316": "GU",
-	"320": "GT",
-	"324": "GN",
-	"328": "GY",
-	"332": "HT",
-	"334": "HM",
-	"336": "VA",
-	"340": "HN",
-	"344": "HK",
-	"348": "HU",
-	"352": "IS",
-	"356": "IN",
-	"360": "ID",
-	"364": "IR",
-	"368": "IQ",
-	"372": "IE",
-	"376": "IL",
-	"380": "IT",
-	"384": "CI",
-	"388": "JM",
-	"392": "JP",
-	"398": "KZ",
-	"400": "JO",
-	"404": "KE",
-	"408": "KP",
-	"410": "KR",
-	"414": "KW",
-	"417": "KG",
-	"418": "LA",
-	"422": "LB",
-	"426": "LS",
-	"428": "LV",
-	"430": "LR",
-	"434": "LY",
-	"438": "LI",
-	"440": "LT",
-	"442": "LU",
-	"446": "MO",
-	"450": "MG",
-	"454": "MW",
-	"458": "MY",
-	"462": "MV",
-	"466": "ML",
-	"470": "MT",
-	"474": "MQ",
-	"478": "MR",
-	"480": "MU",
-	"484": "MX",
-	"492": "MC",
-	"496": "MN",
-	"498": "MD",
-	"499": "ME",
-	"500": "MS",
-	"504": "MA",
-	"508": "MZ",
-	"512": "OM",
-	"516": "NA",
-	"520": "NR",
-	"524": "NP",
-	"528": "NL",
-	"531": "CW",
-	"533": "AW",
-	"534": "SX",
-	"535": "BQ",
-	"540": "NC",
-	"548": "VU",
-	"554": "NZ",
-	"558": "NI",
-	"562": "NE",
-	"566": "NG",
-	"570": "NU",
-	"574": "NF",
-	"578": "NO",
-	"580": "MP",
-	"581": "UM",
-	"583": "FM",
-	"584": "MH",
-	"585": "PW",
-	"586": "PK",
-	"591": "PA",
-	"598": "PG",
-	"600": "PY",
-	"604": "PE",
-	"608": "PH",
-	"612": "PN",
-	"616": "PL",
-	"620": "PT",
-	"624": "GW",
-	"626": "TL",
-	"630": "PR",
-	"634": "QA",
-	"638": "RE",
-	"642": "RO",
-	"643": "RU",
-	"646": "RW",
-	"652": "BL",
-	"654": "SH",
-	"659": "KN",
-	"660": "AI",
-	"662": "LC",
-	"663": "MF",
-	"666": "PM",
-	"670": "VC",
-	"674": "SM",
-	"678": "ST",
-	"682": "SA",
-	"686": "SN",
-	"688": "RS",
-	"690": "SC",
-	"694": "SL",
-	"702": "SG",
-	"703": "SK",
-	"704": "VN",
-	"705": "SI",
-	"706": "SO",
-	"710": "ZA",
-	"716": "ZW",
-	"724": "ES",
-	"728": "SS",
-	"729": "SD",
-	"732": "EH",
-	"740": "SR",
-	"744": "SJ",
-	"748": "SZ",
-	"752": "SE",
-	"756": "CH",
-	"760": "SY",
-	"762": "TJ",
-	"764": "TH",
-	"768": "TG",
-	"772": "TK",
-	"776": "TO",
-	"780": "TT",
-	"784": "AE",
-	"788": "TN",
-	"792": "TR",
-	"795": "TM",
-	"796": "TC",
-	"798": "TV",
-	"800": "UG",
-	"804": "UA",
-	"807": "MK",
-	"818": "EG",
-	"826": "GB",
-	"831": "GG",
-	"832": "JE",
-	"833": "IM",
-	"834": "TZ",
-	"840": "US",
-	"850": "VI",
-	"854": "BF",
-	"858": "UY",
-	"860": "UZ",
-	"862": "VE",
-	"876": "WF",
-	"882": "WS",
-	"887": "YE",
-	"894": "ZM",
-}
+// List of ISO 3166-1 countries, stored as sorted records of the two-letter code followed by the numeric code
+const countryCodes = "" +
+	"AD020" +
+	"AE784" +
+	"AF004" +
+	"AG028" +
+	"AI660" +
+	"AL008" +
+	"AM051" +
+	"AO024" +
+	"AQ010" +
+	"AR032" +
+	"AS016" +
+	"AT040" +
+	"AU036" +
+	"AW533" +
+	"AX248" +
+	"AZ031" +
+	"BA070" +
+	"BB052" +
+	"BD050" +
+	"BE056" +
+	"BF854" +
+	"BG100" +
+	"BH048" +
+	"BI108" +
+	"BJ204" +
+	"BL652" +
+	"BM060" +
+	"BN096" +
+	"BO068" +
+	"BQ535" +
+	"BR076" +
+	"BS044" +
+	"BT064" +
+	"BV074" +
+	"BW072" +
+	"BY112" +
+	"BZ084" +
+	"CA124" +
+	"CC166" +
+	"CD180" +
+	"CF140" +
+	"CG178" +
+	"CH756" +
+	"CI384" +
+	"CK184" +
+	"CL152" +
+	"CM120" +
+	"CN156" +
+	"CO170" +
+	"CR188" +
+	"CU192" +
+	"CV132" +
+	"CW531" +
+	"CX162" +
+	"CY196" +
+	"CZ203" +
+	"DE276" +
+	"DJ262" +
+	"DK208" +
+	"DM212" +
+	"DO214" +
+	"DZ012" +
+	"EC218" +
+	"EE233" +
+	"EG818" +
+	"EH732" +
+	"ER232" +
+	"ES724" +
+	"ET231" +
+	"FI246" +
+	"FJ242" +
+	"FK238" +
+	"FM583" +
+	"FO234" +
+	"FR250" +
+	"GA266" +
+	"GB826" +
+	"GD308" +
+	"GE268" +
+	"GF254" +
+	"GG831" +
+	"GH288" +
+	"GI292" +
+	"GL304" +
+	"GM270" +
+	"GN324" +
+	"GP312" +
+	"GQ226" +
+	"GR300" +
+	"GS239" +
+	"GT320" +
+	"GU316" +
+	"GW624" +
+	"GY328" +
+	"HK344" +
+	"HM334" +
+	"HN340" +
+	"HR191" +
+	"HT332" +
+	"HU348" +
+	"ID360" +
+	"IE372" +
+	"IL376" +
+	"IM833" +
+	"IN356" +
+	"IO086" +
+	"IQ368" +
+	"IR364" +
+	"IS352" +
+	"IT380" +
+	"JE832" +
+	"JM388" +
+	"JO400" +
+	"JP392" +
+	"KE404" +
+	"KG417" +
+	"KH116" +
+	"KI296" +
+	"KM174" +
+	"KN659" +
+	"KP408" +
+	"KR410" +
+	"KW414" +
+	"KY136" +
+	"KZ398" +
+	"LA418" +
+	"LB422" +
+	"LC662" +
+	"LI438" +
+	"LK144" +
+	"LR430" +
+	"LS426" +
+	"LT440" +
+	"LU442" +
+	"LV428" +
+	"LY434" +
+	"MA504" +
+	"MC492" +
+	"MD498" +
+	"ME499" +
+	"MF663" +
+	"MG450" +
+	"MH584" +
+	"MK807" +
+	"ML466" +
+	"MM104" +
+	"MN496" +
+	"MO446" +
+	"MP580" +
+	"MQ474" +
+	"MR478" +
+	"MS500" +
+	"MT470" +
+	"MU480" +
+	"MV462" +
+	"MW454" +
+	"MX484" +
+	"MY458" +
+	"MZ508" +
+	"NA516" +
+	"NC540" +
+	"NE562" +
+	"NF574" +
+	"NG566" +
+	"NI558" +
+	"NL528" +
+	"NO578" +
+	"NP524" +
+	"NR520" +
+	"NU570" +
+	"NZ554" +
+	"OM512" +
+	"PA591" +
+	"PE604" +
+	"PF258" +
+	"PG598" +
+	"PH608" +
+	"PK586" +
+	"PL616" +
+	"PM666" +
+	"PN612" +
+	"PR630" +
+	"PS275" +
+	"PT620" +
+	"PW585" +
+	"PY600" +
+	"QA634" +
+	"RE638" +
+	"RO642" +
+	"RS688" +
+	"RU643" +
+	"RW646" +
+	"SA682" +
+	"SB090" +
+	"SC690" +
+	"SD729" +
+	"SE752" +
+	"SG702" +
+	"SH654" +
+	"SI705" +
+	"SJ744" +
+	"SK703" +
+	"SL694" +
+	"SM674" +
+	"SN686" +
+	"SO706" +
+	"SR740" +
+	"SS728" +
+	"ST678" +
+	"SV222" +
+	"SX534" +
+	"SY760" +
+	"SZ748" +
+	"TC796" +
+	"TD148" +
+	"TF260" +
+	"TG768" +
+	"TH764" +
+	"TJ762" +
+	"TK772" +
+	"TL626" +
+	"TM795" +
+	"TN788" +
+	"TO776" +
+	"TR792" +
+	"TT780" +
+	"TV798" +
+	"TW158" +
+	"TZ834" +
+	"UA804" +
+	"UG800" +
+	"UM581" +
+	"US840" +
+	"UY858" +
+	"UZ860" +
+	"VA336" +
+	"VC670" +
+	"VE862" +
+	"VG092" +
+	"VI850" +
+	"VN704" +
+	"VU548" +
+	"WF876" +
+	"WS882" +
+	"YE887" +
+	"YT175" +
+	"ZA710" +
+	"ZM894" +
+	"ZW716"
+
+// List of ISO 3166-1 countries, stored as sorted records of the numeric code followed by the two-letter code
+const countryNumbers = "" +
+	"004AF" +
+	"008AL" +
+	"010AQ" +
+	"012DZ" +
+	"016AS" +
+	"020AD" +
+	"024AO" +
+	"028AG" +
+	"031AZ" +
+	"032AR" +
+	"036AU" +
+	"040AT" +
+	"044BS" +
+	"048BH" +
+	"050BD" +
+	"051AM" +
+	"052BB" +
+	"056BE" +
+	"060BM" +
+	"064BT" +
+	"068BO" +
+	"070BA" +
+	"072BW" +
+	"074BV" +
+	"076BR" +
+	"084BZ" +
+	"086IO" +
+	"090SB" +
+	"092VG" +
+	"096BN" +
+	"100BG" +
+	"104MM" +
+	"108BI" +
+	"112BY" +
+	"116KH" +
+	"120CM" +
+	"124CA" +
+	"132CV" +
+	"136KY" +
+	"140CF" +
+	"144LK" +
+	"148TD" +
+	"152CL" +
+	"156CN" +
+	"158TW" +
+	"162CX" +
+	"166CC" +
+	"170CO" +
+	"174KM" +
+	"175YT" +
+	"178CG" +
+	"180CD" +
+	"184CK" +
+	"188CR" +
+	"191HR" +
+	"192CU" +
+	"196CY" +
+	"203CZ" +
+	"204BJ" +
+	"208DK" +
+	"212DM" +
+	"214DO" +
+	"218EC" +
+	"222SV" +
+	"226GQ" +
+	"231ET" +
+	"232ER" +
+	"233EE" +
+	"234FO" +
+	"238FK" +
+	"239GS" +
+	"242FJ" +
+	"246FI" +
+	"248AX" +
+	"250FR" +
+	"254GF" +
+	"258PF" +
+	"260TF" +
+	"262DJ" +
+	"266GA" +
+	"268GE" +
+	"270GM" +
+	"275PS" +
+	"276DE" +
+	"288GH" +
+	"292GI" +
+	"296KI" +
+	"300GR" +
+	"304GL" +
+	"308GD" +
+	"312GP" +
+	"316GU" +
+	"320GT" +
+	"324GN" +
+	"328GY" +
+	"332HT" +
+	"334HM" +
+	"336VA" +
+	"340HN" +
+	"344HK" +
+	"348HU" +
+	"352IS" +
+	"356IN" +
+	"360ID" +
+	"364IR" +
+	"368IQ" +
+	"372IE" +
+	"376IL" +
+	"380IT" +
+	"384CI" +
+	"388JM" +
+	"392JP" +
+	"398KZ" +
+	"400JO" +
+	"404KE" +
+	"408KP" +
+	"410KR" +
+	"414KW" +
+	"417KG" +
+	"418LA" +
+	"422LB" +
+	"426LS" +
+	"428LV" +
+	"430LR" +
+	"434LY" +
+	"438LI" +
+	"440LT" +
+	"442LU" +
+	"446MO" +
+	"450MG" +
+	"454MW" +
+	"458MY" +
+	"462MV" +
+	"466ML" +
+	"470MT" +
+	"474MQ" +
+	"478MR" +
+	"480MU" +
+	"484MX" +
+	"492MC" +
+	"496MN" +
+	"498MD" +
+	"499ME" +
+	"500MS" +
+	"504MA" +
+	"508MZ" +
+	"512OM" +
+	"516NA" +
+	"520NR" +
+	"524NP" +
+	"528NL" +
+	"531CW" +
+	"533AW" +
+	"534SX" +
+	"535BQ" +
+	"540NC" +
+	"548VU" +
+	"554NZ" +
+	"558NI" +
+	"562NE" +
+	"566NG" +
+	"570NU" +
+	"574NF" +
+	"578NO" +
+	"580MP" +
+	"581UM" +
+	"583FM" +
+	"584MH" +
+	"585PW" +
+	"586PK" +
+	"591PA" +
+	"598PG" +
+	"600PY" +
+	"604PE" +
+	"608PH" +
+	"612PN" +
+	"616PL" +
+	"620PT" +
+	"624GW" +
+	"626TL" +
+	"630PR" +
+	"634QA" +
+	"638RE" +
+	"642RO" +
+	"643RU" +
+	"646RW" +
+	"652BL" +
+	"654SH" +
+	"659KN" +
+	"660AI" +
+	"662LC" +
+	"663MF" +
+	"666PM" +
+	"670VC" +
+	"674SM" +
+	"678ST" +
+	"682SA" +
+	"686SN" +
+	"688RS" +
+	"690SC" +
+	"694SL" +
+	"702SG" +
+	"703SK" +
+	"704VN" +
+	"705SI" +
+	"706SO" +
+	"710ZA" +
+	"716ZW" +
+	"724ES" +
+	"728SS" +
+	"729SD" +
+	"732EH" +
+	"740SR" +
+	"744SJ" +
+	"748SZ" +
+	"752SE" +
+	"756CH" +
+	"760SY" +
+	"762TJ" +
+	"764TH" +
+	"768TG" +
+	"772TK" +
+	"776TO" +
+	"780TT" +
+	"784AE" +
+	"788TN" +
+	"792TR" +
+	"795TM" +
+	"796TC" +
+	"798TV" +
+	"800UG" +
+	"804UA" +
+	"807MK" +
+	"818EG" +
+	"826GB" +
+	"831GG" +
+	"832JE" +
+	"833IM" +
+	"834TZ" +
+	"840US" +
+	"850VI" +
+	"854BF" +
+	"858UY" +
+	"860UZ" +
+	"862VE" +
+	"876WF" +
+	"882WS" +
+	"887YE" +
+	"894ZM"
+
+// List of the languages written in several scripts (from the CLDR likely subtags), stored as sorted records of the
+// two-letter code followed by the script used when the region does not imply another one
+const scriptLanguages = "" +
+	"azLatn" +
+	"srCyrl" +
+	"zhHans"
+
+// List of the regions using another script than the default one of the language (from the CLDR likely subtags),
+// stored as sorted records of the two-letter language code and the two-letter region code followed by the script
+const scriptLanguageRegions = "" +
+	"azIQArab" +
+	"azIRArab" +
+	"azRUCyrl" +
+	"srMELatn" +
+	"srROLatn" +
+	"srTRLatn" +
+	"zhHKHant" +
+	"zhMOHant" +
+	"zhTWHant"
+
+var (
+	languageSet1Index   = tableIndex{table: languageSet1, keySize: 2, valueSize: 3, position: letterPosition, size: 26 * 26}
+	languageSet2Index   = tableIndex{table: languageSet2, keySize: 3, valueSize: 3, position: letterPosition, size: 26 * 26 * 26}
+	countryCodesIndex   = tableIndex{table: countryCodes, keySize: 2, valueSize: 3, position: letterPosition, size: 26 * 26}
+	countryNumbersIndex = tableIndex{table: countryNumbers, keySize: 3, valueSize: 2, position: digitPosition, size: 1000}
+)
 
 type Language struct {
 	Language string
@@ -1451,6 +1663,129 @@ func NewLanguage(s string) Language {
 	return language
 }
 
+// Converts the Language to a language tag string with the conventional case of the subtags (e.g. de-Latn-CH).
+func (language Language) String() string {
+	// RFC 5646, 2.1.1. Formatting of Language Tags
+	var stringBuilder strings.Builder
+
+	if len(language.Language) > 0 {
+		stringBuilder.Grow(len(language.Language) + len(language.Script) + len(language.Region) + len(language.Variant) + 3)
+		writeSubtag(&stringBuilder, language.Language, false, false)
+
+		for _, subtag := range []struct {
+			value                   string
+			upperFirst, upperOthers bool
+		}{
+			{value: language.Script, upperFirst: true, upperOthers: false},
+			{value: language.Region, upperFirst: true, upperOthers: true},
+			{value: language.Variant, upperFirst: false, upperOthers: false},
+		} {
+			if len(subtag.value) > 0 {
+				stringBuilder.WriteByte('-')
+				writeSubtag(&stringBuilder, subtag.value, subtag.upperFirst, subtag.upperOthers)
+			}
+		}
+	}
+
+	return stringBuilder.String()
+}
+
+func writeSubtag(stringBuilder *strings.Builder, subtag string, upperFirst, upperOthers bool) {
+	for i := 0; i < len(subtag); i++ {
+		c := subtag[i]
+		if upper := (i == 0 && upperFirst) || (i > 0 && upperOthers); upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		} else if !upper && c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		stringBuilder.WriteByte(c)
+	}
+}
+
+// Equal checks whether the provided language is the same as this one ignoring the case of the subtags.
+// The language codes are compared in their canonical form, so that the ISO 639 set 2 terminology and bibliographic
+// codes are equal to the ISO 639 set 1 code of the same language (e.g. de, deu and ger).
+func (language Language) Equal(l Language) bool {
+	return getCanonicalLanguageCode(language.Language) == getCanonicalLanguageCode(l.Language) &&
+		strings.EqualFold(language.Script, l.Script) &&
+		strings.EqualFold(language.Region, l.Region) &&
+		strings.EqualFold(language.Variant, l.Variant)
+}
+
+// Matches checks whether the language matches the basic language range (e.g. de, de-CH or *).
+// An empty language range matches no language, not even an empty one.
+func (language Language) Matches(languageRange string) bool {
+	// RFC 4647, 3.3.1. Basic Filtering
+	if languageRange == "*" {
+		return true
+	}
+
+	if len(languageRange) == 0 {
+		return false
+	}
+
+	tag := language.String()
+	if len(tag) < len(languageRange) || !strings.EqualFold(tag[:len(languageRange)], languageRange) {
+		return false
+	}
+
+	return len(tag) == len(languageRange) || tag[len(languageRange)] == '-'
+}
+
+// MatchesAny checks whether the language matches any of the specified basic language ranges
+func (language Language) MatchesAny(languageRanges ...string) bool {
+	for _, languageRange := range languageRanges {
+		if language.Matches(languageRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare compares the languages subtag by subtag ignoring the case and returns -1, 0 or 1 if this language
+// sorts before, the same as or after the provided one. Unlike Equal it compares the language codes as they are, so
+// de sorts before deu
+func (language Language) Compare(l Language) int {
+	for _, subtags := range [][2]string{
+		{language.Language, l.Language},
+		{language.Script, l.Script},
+		{language.Region, l.Region},
+		{language.Variant, l.Variant},
+	} {
+		if result := compareFold(subtags[0], subtags[1]); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+func compareFold(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := a[i], b[i]
+		if ca >= 'A' && ca <= 'Z' {
+			ca += 'a' - 'A'
+		}
+		if cb >= 'A' && cb <= 'Z' {
+			cb += 'a' - 'A'
+		}
+
+		if ca < cb {
+			return -1
+		} else if ca > cb {
+			return 1
+		}
+	}
+
+	if len(a) < len(b) {
+		return -1
+	} else if len(a) > len(b) {
+		return 1
+	}
+
+	return 0
+}
+
 // ParseLanguage parses the given string as a language and returns it as a Language.
 // If the string cannot be parsed an appropriate error is returned.
 func ParseLanguage(s string) (Language, error) {
@@ -1501,17 +1836,35 @@ func consumeTag(s string) (language, remaining string, consumed bool) {
 
 func isValidLanguage(language string) bool {
 	if len(language) == 2 {
-		_, ok := languageSet1[strings.ToLower(language)]
-		return ok
+		return languageSet1Index.contains(language)
 	} else if len(language) == 3 {
-		_, ok := languageSet2[strings.ToLower(language)]
-		return ok
+		return languageSet2Index.contains(language)
 	}
 
 	return false
 }
 
 // Capitalize the first letter and make the rest lowercase
+// getCanonicalLanguageCode converts the language code to lower case and replaces the three-letter codes with the
+// two-letter code of the same language if there is one (e.g. deu and ger with de).
+func getCanonicalLanguageCode(code string) string {
+	code = strings.ToLower(code)
+	if len(code) != 3 {
+		return code
+	}
+
+	terminologyCode, found := lookupTable(languageSet2, 3, 3, code)
+	if !found {
+		return code
+	}
+
+	if twoLetterCode, found := lookupTable(languageSet1Terminology, 3, 2, terminologyCode); found {
+		return twoLetterCode
+	}
+
+	return terminologyCode
+}
+
 func capitalize(s string) string {
 	if len(s) == 0 {
 		return s
@@ -1523,8 +1876,19 @@ func capitalize(s string) string {
 
 func isValidScript(script string) bool {
 	if len(script) == 4 {
-		_, ok := scripts[capitalize(script)]
-		return ok
+		// capitalize into a fixed-size buffer to avoid allocating
+		var key [4]byte
+		for i := 0; i < len(key); i++ {
+			key[i] = script[i]
+			if i == 0 && key[i] >= 'a' && key[i] <= 'z' {
+				key[i] -= 'a' - 'A'
+			} else if i > 0 && key[i] >= 'A' && key[i] <= 'Z' {
+				key[i] += 'a' - 'A'
+			}
+		}
+
+		_, found := lookupTable(scripts, 4, 3, string(key[:]))
+		return found
 	}
 
 	return false
@@ -1532,11 +1896,9 @@ func isValidScript(script string) bool {
 
 func isValidCountry(country string) bool {
 	if len(country) == 2 {
-		_, ok := countryCodes[strings.ToUpper(country)]
-		return ok
+		return countryCodesIndex.contains(country)
 	} else if len(country) == 3 {
-		_, ok := countryNumbers[strings.ToUpper(country)]
-		return ok
+		return countryNumbersIndex.contains(country)
 	}
 
 	return false
@@ -34,3 +34,134 @@ func TestNewLanguage(t *testing.T) {
 		})
 	}
 }
+
+func TestLanguageString(t *testing.T) {
+	testCases := []struct {
+		name   string
+		value  contenttype.Language
+		result string
+	}{
+		{name: "Empty language", value: contenttype.Language{}, result: ""},
+		{name: "Language only", value: contenttype.Language{Language: "lt"}, result: "lt"},
+		{name: "Language and region", value: contenttype.Language{Language: "lv", Region: "LV"}, result: "lv-LV"},
+		{name: "Language, script, and region", value: contenttype.Language{Language: "zh", Script: "Hant", Region: "TW"}, result: "zh-Hant-TW"},
+		{name: "Language, region, and variant", value: contenttype.Language{Language: "de", Region: "CH", Variant: "1901"}, result: "de-CH-1901"},
+		{name: "Unconventional case", value: contenttype.Language{Language: "DE", Script: "lATN", Region: "ch", Variant: "ROZAJ"}, result: "de-Latn-CH-rozaj"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := testCase.value.String()
+
+			if result != testCase.result {
+				t.Errorf("Invalid result, got %s, expected %s", result, testCase.result)
+			}
+		})
+	}
+}
+
+func TestLanguageEqual(t *testing.T) {
+	testCases := []struct {
+		name   string
+		a      contenttype.Language
+		b      contenttype.Language
+		result bool
+	}{
+		{name: "Empty languages", a: contenttype.Language{}, b: contenttype.Language{}, result: true},
+		{name: "Same language", a: contenttype.NewLanguage("de-CH"), b: contenttype.NewLanguage("de-CH"), result: true},
+		{name: "Different case", a: contenttype.Language{Language: "DE", Region: "ch"}, b: contenttype.NewLanguage("de-CH"), result: true},
+		{name: "Different region", a: contenttype.NewLanguage("de-CH"), b: contenttype.NewLanguage("de-AT"), result: false},
+		{name: "Missing script", a: contenttype.NewLanguage("zh-Hant-TW"), b: contenttype.NewLanguage("zh-TW"), result: false},
+		{name: "Different variant", a: contenttype.NewLanguage("de-CH-1901"), b: contenttype.NewLanguage("de-CH-1996"), result: false},
+		{name: "Terminology code", a: contenttype.NewLanguage("deu-CH"), b: contenttype.NewLanguage("de-CH"), result: true},
+		{name: "Bibliographic code", a: contenttype.NewLanguage("ger"), b: contenttype.NewLanguage("de"), result: true},
+		{name: "Bibliographic and terminology codes", a: contenttype.NewLanguage("ger"), b: contenttype.Language{Language: "DEU"}, result: true},
+		{name: "Three-letter code only", a: contenttype.NewLanguage("haw"), b: contenttype.NewLanguage("haw"), result: true},
+		{name: "Different three-letter code", a: contenttype.NewLanguage("deu"), b: contenttype.NewLanguage("fra"), result: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.a.Equal(testCase.b); result != testCase.result {
+				t.Errorf("Invalid equality, got %v, expected %v for %s and %s", result, testCase.result, testCase.a, testCase.b)
+			}
+		})
+	}
+}
+
+func TestLanguageMatches(t *testing.T) {
+	testCases := []struct {
+		name          string
+		language      contenttype.Language
+		languageRange string
+		result        bool
+	}{
+		{name: "Wildcard", language: contenttype.NewLanguage("de-CH"), languageRange: "*", result: true},
+		{name: "Exact match", language: contenttype.NewLanguage("de-CH"), languageRange: "de-CH", result: true},
+		{name: "Case-insensitive match", language: contenttype.NewLanguage("de-CH"), languageRange: "DE-ch", result: true},
+		{name: "Prefix", language: contenttype.NewLanguage("de-CH"), languageRange: "de", result: true},
+		{name: "Prefix with script", language: contenttype.NewLanguage("zh-Hant-TW"), languageRange: "zh-Hant", result: true},
+		{name: "Partial subtag", language: contenttype.NewLanguage("den"), languageRange: "de", result: false},
+		{name: "More specific range", language: contenttype.NewLanguage("de"), languageRange: "de-CH", result: false},
+		{name: "Different language", language: contenttype.NewLanguage("fr-CH"), languageRange: "de", result: false},
+		{name: "Skipped subtag", language: contenttype.NewLanguage("zh-Hant-TW"), languageRange: "zh-TW", result: false},
+		{name: "Empty range", language: contenttype.NewLanguage("de"), languageRange: "", result: false},
+		{name: "Empty range and language", language: contenttype.Language{}, languageRange: "", result: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.language.Matches(testCase.languageRange); result != testCase.result {
+				t.Errorf("Invalid match, got %v, expected %v for %s and %s", result, testCase.result, testCase.language, testCase.languageRange)
+			}
+		})
+	}
+}
+
+func TestLanguageMatchesAny(t *testing.T) {
+	testCases := []struct {
+		name           string
+		language       contenttype.Language
+		languageRanges []string
+		result         bool
+	}{
+		{name: "No ranges", language: contenttype.NewLanguage("de"), languageRanges: nil, result: false},
+		{name: "Single match", language: contenttype.NewLanguage("de"), languageRanges: []string{"de"}, result: true},
+		{name: "Second range matches", language: contenttype.NewLanguage("de-CH"), languageRanges: []string{"fr", "de"}, result: true},
+		{name: "No match", language: contenttype.NewLanguage("de-CH"), languageRanges: []string{"fr", "de-AT"}, result: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.language.MatchesAny(testCase.languageRanges...); result != testCase.result {
+				t.Errorf("Invalid match, got %v, expected %v for %s and %v", result, testCase.result, testCase.language, testCase.languageRanges)
+			}
+		})
+	}
+}
+
+func TestLanguageCompare(t *testing.T) {
+	testCases := []struct {
+		name   string
+		a      contenttype.Language
+		b      contenttype.Language
+		result int
+	}{
+		{name: "Equal languages", a: contenttype.NewLanguage("de-CH"), b: contenttype.NewLanguage("de-CH"), result: 0},
+		{name: "Different case", a: contenttype.Language{Language: "DE"}, b: contenttype.NewLanguage("de"), result: 0},
+		{name: "Language before", a: contenttype.NewLanguage("de"), b: contenttype.NewLanguage("en"), result: -1},
+		{name: "Language after", a: contenttype.NewLanguage("fr"), b: contenttype.NewLanguage("en"), result: 1},
+		{name: "Shorter language before", a: contenttype.NewLanguage("de"), b: contenttype.NewLanguage("deu"), result: -1},
+		{name: "Missing region before", a: contenttype.NewLanguage("de"), b: contenttype.NewLanguage("de-AT"), result: -1},
+		{name: "Region after", a: contenttype.NewLanguage("de-CH"), b: contenttype.NewLanguage("de-AT"), result: 1},
+		{name: "Script before region", a: contenttype.NewLanguage("zh-Hant-TW"), b: contenttype.NewLanguage("zh-TW"), result: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.a.Compare(testCase.b); result != testCase.result {
+				t.Errorf("Invalid comparison, got %v, expected %v for %s and %s", result, testCase.result, testCase.a, testCase.b)
+			}
+		})
+	}
+}
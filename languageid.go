@@ -0,0 +1,137 @@
+package contenttype
+
+// LanguageID is a compact, comparable representation of a Language suitable for map keys.
+// Languages consisting of a language code, an optional script and an optional region are packed into an integer.
+// The remaining languages (e.g. the ones with a variant) are kept in the LanguageID as they are, so creating IDs
+// never retains any memory past the lifetime of the IDs themselves.
+type LanguageID struct {
+	packed   uint64
+	overflow Language // the language if it can't be packed
+}
+
+const (
+	// layout of a packed LanguageID:
+	// bits 0-14: language, three 5-bit letters
+	// bits 15-34: script, four 5-bit letters
+	// bits 35-45: region, two 5-bit letters or a 10-bit number with bit 45 set
+	languageIDScriptShift = 15
+	languageIDRegionShift = 35
+	languageIDNumericFlag = 1 << 10
+)
+
+// ID returns the LanguageID of the language.
+func (language Language) ID() LanguageID {
+	if packed, ok := packLanguage(language); ok {
+		return LanguageID{packed: packed}
+	}
+
+	return LanguageID{overflow: language}
+}
+
+// Language converts the LanguageID back to the Language it was created from.
+func (id LanguageID) Language() Language {
+	if id.overflow != (Language{}) {
+		return id.overflow
+	}
+
+	language := Language{
+		Language: unpackLetters(id.packed&0x7FFF, 'a', 'a'),
+		Script:   unpackLetters(id.packed>>languageIDScriptShift&0xFFFFF, 'A', 'a'),
+	}
+
+	region := id.packed >> languageIDRegionShift & 0x7FF
+	if region&languageIDNumericFlag != 0 {
+		number := region &^ languageIDNumericFlag
+		language.Region = string([]byte{byte('0' + number/100), byte('0' + number/10%10), byte('0' + number%10)})
+	} else {
+		language.Region = unpackLetters(region, 'A', 'A')
+	}
+
+	return language
+}
+
+// Converts the LanguageID to a language tag string.
+func (id LanguageID) String() string {
+	return id.Language().String()
+}
+
+func packLanguage(language Language) (uint64, bool) {
+	if len(language.Variant) > 0 {
+		return 0, false
+	}
+
+	if len(language.Language) == 0 {
+		return 0, len(language.Script) == 0 && len(language.Region) == 0
+	}
+
+	if len(language.Language) != 2 && len(language.Language) != 3 {
+		return 0, false
+	}
+	packedLanguage, ok := packLetters(language.Language, 'a', 'a')
+	if !ok {
+		return 0, false
+	}
+
+	if len(language.Script) != 0 && len(language.Script) != 4 {
+		return 0, false
+	}
+	packedScript, ok := packLetters(language.Script, 'A', 'a')
+	if !ok {
+		return 0, false
+	}
+
+	var packedRegion uint64
+	switch {
+	case len(language.Region) == 0:
+	case len(language.Region) == 2:
+		if packedRegion, ok = packLetters(language.Region, 'A', 'A'); !ok {
+			return 0, false
+		}
+	case len(language.Region) == 3:
+		for i := 0; i < len(language.Region); i++ {
+			if !isDigitChar(language.Region[i]) {
+				return 0, false
+			}
+			packedRegion = packedRegion*10 + uint64(language.Region[i]-'0')
+		}
+		packedRegion |= languageIDNumericFlag
+	default:
+		return 0, false
+	}
+
+	return packedLanguage | packedScript<<languageIDScriptShift | packedRegion<<languageIDRegionShift, true
+}
+
+func packLetters(s string, first, rest byte) (packed uint64, ok bool) {
+	for i := 0; i < len(s); i++ {
+		base := rest
+		if i == 0 {
+			base = first
+		}
+
+		if s[i] < base || s[i] >= base+26 {
+			return 0, false
+		}
+
+		packed |= uint64(s[i]-base+1) << (5 * uint(i))
+	}
+
+	return packed, true
+}
+
+func unpackLetters(packed uint64, first, rest byte) string {
+	var letters [4]byte
+
+	count := 0
+	for ; packed != 0 && count < len(letters); count++ {
+		base := rest
+		if count == 0 {
+			base = first
+		}
+
+		letters[count] = base + byte(packed&0x1F) - 1
+		packed >>= 5
+	}
+
+	return string(letters[:count])
+}
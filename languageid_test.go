@@ -0,0 +1,56 @@
+package contenttype_test
+
+import (
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestLanguageID(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value contenttype.Language
+	}{
+		{name: "Empty language", value: contenttype.Language{}},
+		{name: "Language only", value: contenttype.Language{Language: "lt"}},
+		{name: "Three letter language", value: contenttype.Language{Language: "lav"}},
+		{name: "Language and region", value: contenttype.Language{Language: "lv", Region: "LV"}},
+		{name: "Language, script, and region", value: contenttype.Language{Language: "zh", Script: "Hant", Region: "TW"}},
+		{name: "Language and region number", value: contenttype.Language{Language: "es", Region: "419"}},
+		{name: "Language and leading zero region number", value: contenttype.Language{Language: "en", Region: "036"}},
+		{name: "Language and variant", value: contenttype.Language{Language: "sl", Variant: "rozaj"}},
+		{name: "Language, region, and variant", value: contenttype.Language{Language: "de", Region: "CH", Variant: "1901"}},
+		{name: "Upper-case language", value: contenttype.Language{Language: "EN", Region: "us"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			id := testCase.value.ID()
+
+			if result := id.Language(); result != testCase.value {
+				t.Errorf("Invalid language, got %v, expected %v", result, testCase.value)
+			}
+
+			if again := testCase.value.ID(); again != id {
+				t.Errorf("Unstable ID, got %v, expected %v for %v", again, id, testCase.value)
+			}
+		})
+	}
+}
+
+func TestLanguageIDDistinct(t *testing.T) {
+	languages := []string{"en", "en-US", "en-GB", "en-Latn-US", "eng", "de", "de-CH", "de-CH-1901", "sl-rozaj", "lv-428", "zh-Hans", "zh-Hant"}
+	ids := map[contenttype.LanguageID]string{}
+
+	for _, language := range languages {
+		id := contenttype.NewLanguage(language).ID()
+		if previous, found := ids[id]; found {
+			t.Errorf("Duplicate ID %v for %s and %s", id, previous, language)
+		}
+		ids[id] = language
+
+		if id.String() != language {
+			t.Errorf("Invalid string, got %s, expected %s", id.String(), language)
+		}
+	}
+}
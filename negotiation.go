@@ -0,0 +1,109 @@
+package contenttype
+
+import (
+	"net/http"
+	"sort"
+)
+
+// RankedMediaType is an available media type with the quality value the Accept header assigns to it.
+type RankedMediaType struct {
+	MediaType MediaType
+	Quality   float64
+	// MediaRange is the most specific media range of the Accept header matching the media type,
+	// its MediaType is empty if no media range matches.
+	MediaRange MediaRange
+}
+
+// NegotiationResult is the outcome of a media type negotiation.
+type NegotiationResult struct {
+	// MediaType is the selected media type.
+	MediaType MediaType
+	// Quality is the quality value of the selected media type.
+	Quality float64
+	// MediaRange is the media range of the Accept header that matched the selected media type.
+	MediaRange MediaRange
+	// Ranking lists all the available media types from the most to the least acceptable one, including the ones
+	// excluded with q=0 or not matched by any media range.
+	Ranking []RankedMediaType
+}
+
+// NegotiateMediaType chooses a media type from available media types according to the Accept header using the given
+// options like GetAcceptableMediaTypeWithOptions does, and returns the full result of the negotiation.
+// If the request does not contain the Accept header, all the available media types are acceptable in their order.
+// If no type can be selected, ErrNoAcceptableTypeFound is returned along with the ranking.
+func NegotiateMediaType(request *http.Request, availableMediaTypes []MediaType, options NegotiationOptions) (NegotiationResult, error) {
+	// RFC 7231, 5.3.2. Accept
+	if len(availableMediaTypes) == 0 {
+		return NegotiationResult{}, ErrNoAvailableTypeGiven
+	}
+
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+	if !found {
+		ranking := make([]RankedMediaType, len(availableMediaTypes))
+		for i, availableMediaType := range availableMediaTypes {
+			ranking[i] = RankedMediaType{MediaType: availableMediaType, Quality: 1}
+		}
+
+		return NegotiationResult{
+			MediaType: availableMediaTypes[0],
+			Quality:   1,
+			Ranking:   ranking,
+		}, nil
+	}
+
+	return NegotiateMediaTypeFromHeader(acceptHeader, availableMediaTypes, options)
+}
+
+// NegotiateMediaTypeFromHeader chooses a media type from available media types according to the specified Accept
+// header value using the given options like GetAcceptableMediaTypeFromHeaderWithOptions does, and returns the full
+// result of the negotiation.
+// If no type can be selected, ErrNoAcceptableTypeFound is returned along with the ranking.
+func NegotiateMediaTypeFromHeader(headerValue string, availableMediaTypes []MediaType, options NegotiationOptions) (NegotiationResult, error) {
+	if len(availableMediaTypes) == 0 {
+		return NegotiationResult{}, ErrNoAvailableTypeGiven
+	}
+
+	weights := make([]mediaTypeWeight, len(availableMediaTypes))
+	if err := getMediaTypeWeights(headerValue, availableMediaTypes, options.MatchSuffix, weights); err != nil {
+		return NegotiationResult{}, err
+	}
+
+	ranking := make([]RankedMediaType, len(availableMediaTypes))
+	for i, availableMediaType := range availableMediaTypes {
+		ranking[i].MediaType = availableMediaType
+		ranking[i].Quality = getQualityValue(weights[i].weight)
+		if len(weights[i].mediaType.Type) > 0 {
+			ranking[i].MediaRange = MediaRange{
+				MediaType:           weights[i].mediaType,
+				Quality:             ranking[i].Quality,
+				ExtensionParameters: parseParameters(weights[i].extensionParameters),
+				Order:               int(weights[i].order),
+			}
+		}
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+
+		if a.Quality != b.Quality {
+			return a.Quality > b.Quality
+		}
+
+		if matchedA, matchedB := len(a.MediaRange.MediaType.Type) > 0, len(b.MediaRange.MediaType.Type) > 0; matchedA != matchedB {
+			return matchedA
+		}
+
+		return a.MediaRange.Order < b.MediaRange.Order
+	})
+
+	if ranking[0].Quality == 0 {
+		return NegotiationResult{Ranking: ranking}, ErrNoAcceptableTypeFound
+	}
+
+	return NegotiationResult{
+		MediaType:  ranking[0].MediaType,
+		Quality:    ranking[0].Quality,
+		MediaRange: ranking[0].MediaRange,
+		Ranking:    ranking,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+		contenttype.NewMediaType("text/html"),
+		contenttype.NewMediaType("application/vnd.api+json"),
+	}
+
+	testCases := []struct {
+		name       string
+		header     string
+		options    contenttype.NegotiationOptions
+		result     contenttype.MediaType
+		quality    float64
+		mediaRange contenttype.MediaType
+		ranking    []string
+		qualities  []float64
+	}{
+		{
+			name:      "No header",
+			result:    contenttype.NewMediaType("application/json"),
+			quality:   1,
+			ranking:   []string{"application/json", "application/xml", "text/html", "application/vnd.api+json"},
+			qualities: []float64{1, 1, 1, 1},
+		},
+		{
+			name:       "Weighted ranges",
+			header:     "text/html, application/*;q=0.8, application/xml;q=0.9",
+			result:     contenttype.NewMediaType("text/html"),
+			quality:    1,
+			mediaRange: contenttype.NewMediaType("text/html"),
+			ranking:    []string{"text/html", "application/xml", "application/json", "application/vnd.api+json"},
+			qualities:  []float64{1, 0.9, 0.8, 0.8},
+		},
+		{
+			name:       "Excluded types",
+			header:     "application/*;q=0.5, application/json;q=0, text/html;q=0",
+			result:     contenttype.NewMediaType("application/xml"),
+			quality:    0.5,
+			mediaRange: contenttype.NewMediaType("application/*"),
+			ranking:    []string{"application/xml", "application/vnd.api+json", "application/json", "text/html"},
+			qualities:  []float64{0.5, 0.5, 0, 0},
+		},
+		{
+			name:       "Unmatched types after excluded types",
+			header:     "application/xml;q=0.1, text/html;q=0",
+			result:     contenttype.NewMediaType("application/xml"),
+			quality:    0.1,
+			mediaRange: contenttype.NewMediaType("application/xml"),
+			ranking:    []string{"application/xml", "text/html", "application/json", "application/vnd.api+json"},
+			qualities:  []float64{0.1, 0, 0, 0},
+		},
+		{
+			name:       "Suffix match",
+			header:     "application/json;q=0.5, */*;q=0.1",
+			options:    contenttype.NegotiationOptions{MatchSuffix: true},
+			result:     contenttype.NewMediaType("application/json"),
+			quality:    0.5,
+			mediaRange: contenttype.NewMediaType("application/json"),
+			ranking:    []string{"application/json", "application/vnd.api+json", "application/xml", "text/html"},
+			qualities:  []float64{0.5, 0.5, 0.1, 0.1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept", testCase.header)
+			}
+
+			result, err := contenttype.NegotiateMediaType(request, availableMediaTypes, testCase.options)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %s", err, testCase.header)
+			}
+
+			if !result.MediaType.Equal(testCase.result) {
+				t.Errorf("Invalid content type, got %s, expected %s for %s", result.MediaType, testCase.result, testCase.header)
+			}
+
+			if result.Quality != testCase.quality {
+				t.Errorf("Invalid quality, got %v, expected %v for %s", result.Quality, testCase.quality, testCase.header)
+			}
+
+			if result.MediaRange.MediaType.String() != testCase.mediaRange.String() {
+				t.Errorf("Invalid media range, got %s, expected %s for %s", result.MediaRange.MediaType, testCase.mediaRange, testCase.header)
+			}
+
+			ranking := make([]string, len(result.Ranking))
+			qualities := make([]float64, len(result.Ranking))
+			for i, rankedMediaType := range result.Ranking {
+				ranking[i] = rankedMediaType.MediaType.String()
+				qualities[i] = rankedMediaType.Quality
+			}
+
+			if !reflect.DeepEqual(ranking, testCase.ranking) {
+				t.Errorf("Invalid ranking, got %v, expected %v for %s", ranking, testCase.ranking, testCase.header)
+			}
+
+			if !reflect.DeepEqual(qualities, testCase.qualities) {
+				t.Errorf("Invalid qualities, got %v, expected %v for %s", qualities, testCase.qualities, testCase.header)
+			}
+		})
+	}
+}
+
+func TestNegotiateMediaTypeErrors(t *testing.T) {
+	testCases := []struct {
+		name                string
+		header              string
+		availableMediaTypes []contenttype.MediaType
+		err                 error
+		ranking             int
+	}{
+		{name: "No available types", header: "application/json", availableMediaTypes: nil, err: contenttype.ErrNoAvailableTypeGiven},
+		{name: "No acceptable type", header: "application/json, text/html;q=0", availableMediaTypes: []contenttype.MediaType{
+			contenttype.NewMediaType("text/html"),
+			contenttype.NewMediaType("application/xml"),
+		}, err: contenttype.ErrNoAcceptableTypeFound, ranking: 2},
+		{name: "Invalid header", header: "application/", availableMediaTypes: []contenttype.MediaType{
+			contenttype.NewMediaType("application/json"),
+		}, err: contenttype.ErrInvalidMediaType},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			request.Header.Set("Accept", testCase.header)
+
+			result, err := contenttype.NegotiateMediaType(request, testCase.availableMediaTypes, contenttype.NegotiationOptions{})
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+
+			if len(result.Ranking) != testCase.ranking {
+				t.Errorf("Invalid ranking length, got %d, expected %d for %s", len(result.Ranking), testCase.ranking, testCase.header)
+			}
+		})
+	}
+}
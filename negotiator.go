@@ -0,0 +1,202 @@
+package contenttype
+
+import (
+	"math"
+	"net/http"
+)
+
+// TieBreak selects which of the equally acceptable media types a Negotiator chooses.
+type TieBreak int
+
+const (
+	// TieBreakAcceptOrder prefers the media type matched by the media range listed first in the Accept header.
+	TieBreakAcceptOrder TieBreak = iota
+	// TieBreakAvailableOrder prefers the media type listed first in the available media types.
+	TieBreakAvailableOrder
+)
+
+// ParameterMatching selects how the parameters of media ranges are matched against the available media types.
+type ParameterMatching int
+
+const (
+	// ParameterMatchingSubset requires all the parameters of a media range to be present in the media type.
+	ParameterMatchingSubset ParameterMatching = iota
+	// ParameterMatchingExact requires a media range to have the same parameters as the media type.
+	ParameterMatchingExact
+	// ParameterMatchingIgnore ignores the parameters of media ranges.
+	ParameterMatchingIgnore
+)
+
+// NegotiatorOptions alters how a Negotiator chooses an acceptable media type.
+type NegotiatorOptions struct {
+	NegotiationOptions
+	// Default is the media type returned when the request has no Accept header,
+	// the first available media type is returned if it is empty.
+	Default MediaType
+	// TieBreak selects which of the equally acceptable media types is chosen.
+	TieBreak TieBreak
+	// ParameterMatching selects how the parameters of media ranges are matched.
+	ParameterMatching ParameterMatching
+	// MinQuality is the lowest quality value a media type needs to be acceptable.
+	MinQuality float64
+}
+
+// Negotiator chooses acceptable media types from a fixed list of available media types.
+// It is built once with NewNegotiator and is safe for concurrent use.
+type Negotiator struct {
+	availableMediaTypes []MediaType
+	options             NegotiatorOptions
+	minWeight           uint
+	all                 []int
+	typeIndex           map[string][]int
+	subtypeIndex        map[string]map[string][]int
+	suffixIndex         map[string]map[string][]int
+}
+
+// NewNegotiator creates a Negotiator for the given available media types and options.
+// The parameters of the available media types must not be modified afterwards.
+// Returns ErrNoAvailableTypeGiven if the list of available media types is empty.
+func NewNegotiator(availableMediaTypes []MediaType, options NegotiatorOptions) (*Negotiator, error) {
+	if len(availableMediaTypes) == 0 {
+		return nil, ErrNoAvailableTypeGiven
+	}
+
+	if len(options.Default.Type) == 0 {
+		options.Default = availableMediaTypes[0]
+	}
+
+	negotiator := &Negotiator{
+		availableMediaTypes: append([]MediaType(nil), availableMediaTypes...),
+		options:             options,
+		minWeight:           uint(math.Ceil(options.MinQuality * 1000)),
+		all:                 make([]int, len(availableMediaTypes)),
+		typeIndex:           map[string][]int{},
+		subtypeIndex:        map[string]map[string][]int{},
+		suffixIndex:         map[string]map[string][]int{},
+	}
+
+	if negotiator.minWeight == 0 {
+		negotiator.minWeight = 1 // q=0 means "not acceptable"
+	}
+
+	for i, availableMediaType := range negotiator.availableMediaTypes {
+		negotiator.all[i] = i
+		negotiator.typeIndex[availableMediaType.Type] = append(negotiator.typeIndex[availableMediaType.Type], i)
+
+		addToIndex(negotiator.subtypeIndex, availableMediaType.Type, availableMediaType.Subtype, i)
+
+		if suffix := availableMediaType.Suffix(); len(suffix) > 0 {
+			addToIndex(negotiator.suffixIndex, availableMediaType.Type, suffix, i)
+		}
+	}
+
+	return negotiator, nil
+}
+
+// Negotiate chooses a media type from the available media types according to the Accept header.
+// If the request does not contain the Accept header, the default media type is returned.
+// Returns the most suitable media type or an error if no type can be selected.
+func (negotiator *Negotiator) Negotiate(request *http.Request) (MediaType, Parameters, error) {
+	// RFC 7231, 5.3.2. Accept
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+	if !found {
+		return negotiator.options.Default, nil, nil
+	}
+
+	return negotiator.NegotiateFromHeader(acceptHeader)
+}
+
+// NegotiateFromHeader chooses a media type from the available media types according to the specified Accept header
+// value.
+// Returns the most suitable media type or an error if no type can be selected.
+func (negotiator *Negotiator) NegotiateFromHeader(headerValue string) (MediaType, Parameters, error) {
+	var buffer mediaTypeWeightBuffer
+	weights := buffer.get(len(negotiator.availableMediaTypes))
+
+	err := forEachMediaRange(headerValue, func(mediaRange MediaType, weight uint, extensionParameters string, order uint) {
+		for _, i := range negotiator.getCandidates(mediaRange) {
+			availableMediaType := negotiator.availableMediaTypes[i]
+			if negotiator.matchesParameters(mediaRange, availableMediaType) &&
+				getPrecedence(mediaRange, weights[i].mediaType, availableMediaType) {
+				weights[i].mediaType = mediaRange
+				weights[i].extensionParameters = extensionParameters
+				weights[i].weight = weight
+				weights[i].order = order
+			}
+		}
+	})
+	if err != nil {
+		return MediaType{}, nil, err
+	}
+
+	resultIndex := -1
+	for i, weight := range weights {
+		if weight.weight < negotiator.minWeight {
+			continue
+		}
+
+		if resultIndex == -1 || weight.weight > weights[resultIndex].weight ||
+			(weight.weight == weights[resultIndex].weight && negotiator.options.TieBreak == TieBreakAcceptOrder &&
+				weight.order < weights[resultIndex].order) {
+			resultIndex = i
+		}
+	}
+
+	if resultIndex == -1 {
+		return MediaType{}, nil, ErrNoAcceptableTypeFound
+	}
+
+	return negotiator.availableMediaTypes[resultIndex], parseParameters(weights[resultIndex].extensionParameters), nil
+}
+
+// getCandidates returns the indexes of the available media types the type and subtype of the media range match.
+func (negotiator *Negotiator) getCandidates(mediaRange MediaType) []int {
+	// RFC 6839, 2. When to Use a +suffix
+	switch {
+	case mediaRange.Type == "*":
+		return negotiator.all
+	case mediaRange.Subtype == "*":
+		return negotiator.typeIndex[mediaRange.Type]
+	case len(mediaRange.Subtype) > 2 && mediaRange.Subtype[:2] == "*+":
+		return negotiator.suffixIndex[mediaRange.Type][mediaRange.Subtype[2:]]
+	}
+
+	subtypeMatches := negotiator.subtypeIndex[mediaRange.Type][mediaRange.Subtype]
+	if !negotiator.options.MatchSuffix {
+		return subtypeMatches
+	}
+
+	suffixMatches := negotiator.suffixIndex[mediaRange.Type][mediaRange.Subtype]
+	if len(suffixMatches) == 0 {
+		return subtypeMatches
+	}
+
+	return append(append([]int(nil), subtypeMatches...), suffixMatches...)
+}
+
+func (negotiator *Negotiator) matchesParameters(mediaRange, mediaType MediaType) bool {
+	switch negotiator.options.ParameterMatching {
+	case ParameterMatchingIgnore:
+		return true
+	case ParameterMatchingExact:
+		if len(mediaRange.Parameters) != len(mediaType.Parameters) {
+			return false
+		}
+	}
+
+	for key, value := range mediaRange.Parameters {
+		if mediaTypeValue, found := mediaType.Parameters[key]; !found || mediaTypeValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addToIndex(index map[string]map[string][]int, key, subkey string, value int) {
+	if index[key] == nil {
+		index[key] = map[string][]int{}
+	}
+
+	index[key][subkey] = append(index[key][subkey], value)
+}
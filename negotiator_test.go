@@ -0,0 +1,175 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestNegotiator(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+		contenttype.NewMediaType("text/html;level=1"),
+		contenttype.NewMediaType("application/vnd.api+json"),
+	}
+
+	testCases := []struct {
+		name                string
+		header              string
+		options             contenttype.NegotiatorOptions
+		result              contenttype.MediaType
+		extensionParameters contenttype.Parameters
+	}{
+		{name: "No header", result: contenttype.NewMediaType("application/json"), extensionParameters: nil},
+		{name: "No header with default", options: contenttype.NegotiatorOptions{Default: contenttype.NewMediaType("application/xml")}, result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Exact type", header: "application/xml", result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Wildcard", header: "*/*", result: contenttype.NewMediaType("application/json"), extensionParameters: nil},
+		{name: "Subtype wildcard", header: "text/*", result: contenttype.NewMediaType("text/html;level=1"), extensionParameters: nil},
+		{name: "Suffix wildcard", header: "application/*+json", result: contenttype.NewMediaType("application/vnd.api+json"), extensionParameters: nil},
+		{name: "Extension parameters", header: "application/xml;q=1;a=b", result: contenttype.NewMediaType("application/xml"), extensionParameters: contenttype.Parameters{"a": "b"}},
+		{name: "Weights", header: "application/json;q=0.5, application/xml;q=0.8", result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Accept order tie-break", header: "application/xml, application/json", result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Available order tie-break", header: "application/xml, application/json", options: contenttype.NegotiatorOptions{TieBreak: contenttype.TieBreakAvailableOrder}, result: contenttype.NewMediaType("application/json"), extensionParameters: nil},
+		{name: "Suffix match", header: "application/json;q=0.5, application/xml;q=0.8", options: contenttype.NegotiatorOptions{NegotiationOptions: contenttype.NegotiationOptions{MatchSuffix: true}}, result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+		{name: "Suffix match only", header: "text/plain, application/json;q=0.5, application/vnd.api+json;q=0", options: contenttype.NegotiatorOptions{NegotiationOptions: contenttype.NegotiationOptions{MatchSuffix: true}}, result: contenttype.NewMediaType("application/json"), extensionParameters: nil},
+		{name: "Subset parameter matching", header: "text/html;level=1", result: contenttype.NewMediaType("text/html;level=1"), extensionParameters: nil},
+		{name: "Ignored parameter", header: "text/html;level=2", options: contenttype.NegotiatorOptions{ParameterMatching: contenttype.ParameterMatchingIgnore}, result: contenttype.NewMediaType("text/html;level=1"), extensionParameters: nil},
+		{name: "Exact parameter matching", header: "text/html;q=0.5, text/html;level=1", options: contenttype.NegotiatorOptions{ParameterMatching: contenttype.ParameterMatchingExact}, result: contenttype.NewMediaType("text/html;level=1"), extensionParameters: nil},
+		{name: "Minimum quality", header: "application/json;q=0.2, application/xml;q=0.5", options: contenttype.NegotiatorOptions{MinQuality: 0.3}, result: contenttype.NewMediaType("application/xml"), extensionParameters: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			negotiator, err := contenttype.NewNegotiator(availableMediaTypes, testCase.options)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept", testCase.header)
+			}
+
+			result, extensionParameters, err := negotiator.Negotiate(request)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if !result.Equal(testCase.result) {
+				t.Errorf("Invalid content type, got %s, expected %s for %s", result, testCase.result, testCase.header)
+			} else if !reflect.DeepEqual(extensionParameters, testCase.extensionParameters) {
+				t.Errorf("Wrong extension parameters, got %v, expected %v for %s", extensionParameters, testCase.extensionParameters, testCase.header)
+			}
+		})
+	}
+}
+
+func TestNegotiatorErrors(t *testing.T) {
+	availableMediaTypes := []contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("text/html;level=1"),
+	}
+
+	testCases := []struct {
+		name    string
+		header  string
+		options contenttype.NegotiatorOptions
+		err     error
+	}{
+		{name: "No acceptable type", header: "application/xml", err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Excluded type", header: "*/*, application/json;q=0, text/html;q=0", err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Parameter mismatch", header: "text/html;level=2", err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Exact parameter mismatch", header: "text/html", options: contenttype.NegotiatorOptions{ParameterMatching: contenttype.ParameterMatchingExact}, err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Below minimum quality", header: "application/json;q=0.2", options: contenttype.NegotiatorOptions{MinQuality: 0.3}, err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Invalid header", header: "application/", err: contenttype.ErrInvalidMediaType},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			negotiator, err := contenttype.NewNegotiator(availableMediaTypes, testCase.options)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\"", err)
+			}
+
+			_, _, err = negotiator.NegotiateFromHeader(testCase.header)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+		})
+	}
+
+	if _, err := contenttype.NewNegotiator(nil, contenttype.NegotiatorOptions{}); !errors.Is(err, contenttype.ErrNoAvailableTypeGiven) {
+		t.Errorf("Unexpected error \"%v\", expected \"%v\"", err, contenttype.ErrNoAvailableTypeGiven)
+	}
+}
+
+func TestNegotiatorConcurrency(t *testing.T) {
+	negotiator, err := contenttype.NewNegotiator([]contenttype.MediaType{
+		contenttype.NewMediaType("application/json"),
+		contenttype.NewMediaType("application/xml"),
+	}, contenttype.NegotiatorOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for j := 0; j < 100; j++ {
+				result, _, err := negotiator.NegotiateFromHeader("application/xml, application/json;q=0.5")
+				if err != nil || result.Subtype != "xml" {
+					t.Errorf("Invalid result %s, error \"%v\"", result, err)
+					return
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+}
+
+func TestNegotiatorAllocations(t *testing.T) {
+	negotiator, err := contenttype.NewNegotiator(benchmarkMediaTypes, contenttype.NegotiatorOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	for _, benchmarkAcceptHeader := range benchmarkAcceptHeaders {
+		t.Run(benchmarkAcceptHeader.name, func(t *testing.T) {
+			negotiatorAllocations := testing.AllocsPerRun(100, func() {
+				negotiator.NegotiateFromHeader(benchmarkAcceptHeader.header)
+			})
+			functionAllocations := testing.AllocsPerRun(100, func() {
+				contenttype.GetAcceptableMediaTypeFromHeader(benchmarkAcceptHeader.header, benchmarkMediaTypes)
+			})
+
+			if negotiatorAllocations > functionAllocations {
+				t.Errorf("Negotiator allocates more than GetAcceptableMediaTypeFromHeader, got %v, expected at most %v for %s", negotiatorAllocations, functionAllocations, benchmarkAcceptHeader.header)
+			}
+		})
+	}
+}
+
+func BenchmarkNegotiator(b *testing.B) {
+	negotiator, err := contenttype.NewNegotiator(benchmarkMediaTypes, contenttype.NegotiatorOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := negotiator.NegotiateFromHeader(benchmarkAcceptHeaders[0].header); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package contenttype
+
+import (
+	"sync"
+)
+
+// lookupTable finds the key in a table of fixed-size records sorted by key, each consisting of a key immediately
+// followed by its value, and returns the value. Keys must not be longer than four bytes.
+func lookupTable(table string, keySize, valueSize int, key string) (value string, found bool) {
+	recordSize := keySize + valueSize
+	count := len(table) / recordSize
+	if len(key) != keySize || count == 0 {
+		return "", false
+	}
+
+	// the keys are compared as big-endian integers and the search is branchless, which makes it faster than
+	// comparing strings for such short keys
+	packedKey := packKey(key, 0, keySize)
+
+	base := 0
+	for count > 1 {
+		half := count / 2
+		if packKey(table, (base+half)*recordSize, keySize) <= packedKey {
+			base += half
+		}
+		count -= half
+	}
+
+	offset := base * recordSize
+	if packKey(table, offset, keySize) != packedKey {
+		return "", false
+	}
+
+	return table[offset+keySize : offset+recordSize], true
+}
+
+func packKey(s string, offset, size int) uint32 {
+	switch size {
+	case 2:
+		return uint32(s[offset])<<8 | uint32(s[offset+1])
+	case 3:
+		return uint32(s[offset])<<16 | uint32(s[offset+1])<<8 | uint32(s[offset+2])
+	default:
+		return uint32(s[offset])<<24 | uint32(s[offset+1])<<16 | uint32(s[offset+2])<<8 | uint32(s[offset+3])
+	}
+}
+
+// tableIndex is a direct-address bitmap of the keys of a table that is built on first use.
+// It is used for membership tests that must be at least as fast as a map lookup.
+type tableIndex struct {
+	table     string
+	keySize   int
+	valueSize int
+	position  func(key string) int
+	size      int
+
+	once sync.Once
+	bits []uint64
+}
+
+func (index *tableIndex) contains(key string) bool {
+	if len(key) != index.keySize {
+		return false
+	}
+
+	position := index.position(key)
+	if position < 0 {
+		return false
+	}
+
+	index.once.Do(func() {
+		index.bits = make([]uint64, (index.size+63)/64)
+
+		recordSize := index.keySize + index.valueSize
+		for offset := 0; offset < len(index.table); offset += recordSize {
+			p := index.position(index.table[offset : offset+index.keySize])
+			index.bits[p/64] |= 1 << uint(p%64)
+		}
+	})
+
+	return index.bits[position/64]&(1<<uint(position%64)) != 0
+}
+
+// letterPosition returns the position of a key consisting of case-insensitive letters in a base-26 numbering.
+func letterPosition(key string) int {
+	position := 0
+	for i := 0; i < len(key); i++ {
+		c := key[i] | 0x20 // lower-case
+		if c < 'a' || c > 'z' {
+			return -1
+		}
+		position = position*26 + int(c-'a')
+	}
+
+	return position
+}
+
+// digitPosition returns the position of a key consisting of digits in a base-10 numbering.
+func digitPosition(key string) int {
+	position := 0
+	for i := 0; i < len(key); i++ {
+		if !isDigitChar(key[i]) {
+			return -1
+		}
+		position = position*10 + int(key[i]-'0')
+	}
+
+	return position
+}
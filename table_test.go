@@ -0,0 +1,134 @@
+package contenttype
+
+import (
+	"testing"
+)
+
+func TestLookupTable(t *testing.T) {
+	testCases := []struct {
+		name  string
+		key   string
+		value string
+		found bool
+	}{
+		{name: "First record", key: "aa", value: "aar", found: true},
+		{name: "Middle record", key: "en", value: "eng", found: true},
+		{name: "Last record", key: "zu", value: "zul", found: true},
+		{name: "Before first record", key: "a0", found: false},
+		{name: "After last record", key: "zz", found: false},
+		{name: "Missing record", key: "xx", found: false},
+		{name: "Upper-case key", key: "EN", found: false},
+		{name: "Too long key", key: "eng", found: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, found := lookupTable(languageSet1, 2, 3, testCase.key)
+
+			if found != testCase.found || value != testCase.value {
+				t.Errorf("Invalid result, got %s %t, expected %s %t for %s", value, found, testCase.value, testCase.found, testCase.key)
+			}
+		})
+	}
+}
+
+func TestTableIndex(t *testing.T) {
+	testCases := []struct {
+		name      string
+		index     *tableIndex
+		table     string
+		keySize   int
+		valueSize int
+	}{
+		{name: "ISO 639 set 1", index: &languageSet1Index, table: languageSet1, keySize: 2, valueSize: 3},
+		{name: "ISO 639 set 2", index: &languageSet2Index, table: languageSet2, keySize: 3, valueSize: 3},
+		{name: "ISO 3166-1 codes", index: &countryCodesIndex, table: countryCodes, keySize: 2, valueSize: 3},
+		{name: "ISO 3166-1 numbers", index: &countryNumbersIndex, table: countryNumbers, keySize: 3, valueSize: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recordSize := testCase.keySize + testCase.valueSize
+			if len(testCase.table)%recordSize != 0 {
+				t.Fatalf("Invalid table size %d", len(testCase.table))
+			}
+
+			for offset := 0; offset < len(testCase.table); offset += recordSize {
+				key := testCase.table[offset : offset+testCase.keySize]
+				if offset > 0 && testCase.table[offset-recordSize:offset-testCase.valueSize] >= key {
+					t.Errorf("Table is not sorted at %s", key)
+				}
+				if !testCase.index.contains(key) {
+					t.Errorf("Missing key %s", key)
+				}
+				if _, found := lookupTable(testCase.table, testCase.keySize, testCase.valueSize, key); !found {
+					t.Errorf("Key %s not found in table", key)
+				}
+			}
+
+			for _, key := range []string{"", "?", "??", "???", "0a", "a-", "zzzz"} {
+				if testCase.index.contains(key) {
+					t.Errorf("Unexpected key %s", key)
+				}
+			}
+		})
+	}
+}
+
+func TestScriptTables(t *testing.T) {
+	testCases := []struct {
+		name      string
+		table     string
+		keySize   int
+		valueSize int
+	}{
+		{name: "Script languages", table: scriptLanguages, keySize: 2, valueSize: 4},
+		{name: "Script language regions", table: scriptLanguageRegions, keySize: 4, valueSize: 4},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recordSize := testCase.keySize + testCase.valueSize
+			if len(testCase.table)%recordSize != 0 {
+				t.Fatalf("Invalid table size %d", len(testCase.table))
+			}
+
+			for offset := 0; offset < len(testCase.table); offset += recordSize {
+				key := testCase.table[offset : offset+testCase.keySize]
+				if offset > 0 && testCase.table[offset-recordSize:offset-testCase.valueSize] >= key {
+					t.Errorf("Table is not sorted at %s", key)
+				}
+				if !isValidScript(testCase.table[offset+testCase.keySize : offset+recordSize]) {
+					t.Errorf("Invalid script for %s", key)
+				}
+				if _, found := lookupTable(testCase.table, testCase.keySize, testCase.valueSize, key); !found {
+					t.Errorf("Key %s not found in table", key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIsValidLanguage(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		isValidLanguage("en")
+		isValidLanguage("deu")
+		isValidLanguage("xx")
+	}
+}
+
+func BenchmarkIsValidScript(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		isValidScript("Latn")
+		isValidScript("hant")
+		isValidScript("Xxxx")
+	}
+}
+
+func BenchmarkIsValidCountry(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		isValidCountry("US")
+		isValidCountry("428")
+		isValidCountry("XX")
+	}
+}
@@ -0,0 +1,292 @@
+package contenttype
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Variant is a representation of a resource that varies along the media type, language, charset and content coding.
+// Empty fields do not take part in the negotiation.
+type Variant struct {
+	MediaType MediaType
+	Language  Language
+	Charset   string
+	Encoding  string
+	// Quality is the source quality of the variant between 0 and 1 (e.g. lower for a lossy conversion), zero means 1.
+	Quality float64
+}
+
+// VariantScore holds the per-dimension scores of a variant between 0 and 1 and their product.
+type VariantScore struct {
+	MediaType float64
+	Language  float64
+	Charset   float64
+	Encoding  float64
+	Quality   float64
+	Score     float64
+}
+
+// NegotiateVariant chooses a variant according to the Accept, Accept-Language, Accept-Charset and Accept-Encoding
+// headers of the request.
+// Every variant is scored by multiplying its source quality with the quality values the headers assign to its media
+// type, language, charset and content coding; a missing header accepts every value of its dimension. The variant with
+// the highest score is returned with its scores, ties are resolved in favour of the variant listed first.
+// Returns ErrNoAcceptableVariantFound if every variant has the score of zero.
+func NegotiateVariant(request *http.Request, variants []Variant) (Variant, VariantScore, error) {
+	if len(variants) == 0 {
+		return Variant{}, VariantScore{}, ErrNoAvailableVariantGiven
+	}
+
+	scores := make([]VariantScore, len(variants))
+	for i, variant := range variants {
+		scores[i].Quality = variant.Quality
+		if scores[i].Quality == 0 {
+			scores[i].Quality = 1
+		}
+	}
+
+	if err := scoreMediaTypes(request, variants, scores); err != nil {
+		return Variant{}, VariantScore{}, err
+	}
+
+	if err := scoreLanguages(request, variants, scores); err != nil {
+		return Variant{}, VariantScore{}, err
+	}
+
+	if err := scoreCharsets(request, variants, scores); err != nil {
+		return Variant{}, VariantScore{}, err
+	}
+
+	if err := scoreEncodings(request, variants, scores); err != nil {
+		return Variant{}, VariantScore{}, err
+	}
+
+	resultIndex := -1
+	for i := range scores {
+		scores[i].Score = scores[i].Quality * scores[i].MediaType * scores[i].Language * scores[i].Charset * scores[i].Encoding
+
+		if scores[i].Score > 0 && (resultIndex == -1 || scores[i].Score > scores[resultIndex].Score) {
+			resultIndex = i
+		}
+	}
+
+	if resultIndex == -1 {
+		return Variant{}, VariantScore{}, ErrNoAcceptableVariantFound
+	}
+
+	return variants[resultIndex], scores[resultIndex], nil
+}
+
+func scoreMediaTypes(request *http.Request, variants []Variant, scores []VariantScore) error {
+	// RFC 7231, 5.3.2. Accept
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+
+	mediaTypes := make([]MediaType, len(variants))
+	for i, variant := range variants {
+		mediaTypes[i] = variant.MediaType
+	}
+
+	var weights []mediaTypeWeight
+	if found {
+		weights = make([]mediaTypeWeight, len(mediaTypes))
+		if err := getMediaTypeWeights(acceptHeader, mediaTypes, false, weights); err != nil {
+			return err
+		}
+	}
+
+	for i, mediaType := range mediaTypes {
+		if weights == nil || len(mediaType.Type) == 0 {
+			scores[i].MediaType = 1
+		} else {
+			scores[i].MediaType = getQualityValue(weights[i].weight)
+		}
+	}
+
+	return nil
+}
+
+func scoreLanguages(request *http.Request, variants []Variant, scores []VariantScore) error {
+	// RFC 7231, 5.3.5. Accept-Language
+	acceptLanguageHeader, found := getListHeader(request.Header, "Accept-Language")
+
+	var languageRanges []languageRange
+	if found {
+		weightedTokens, err := parseWeightedTokens(acceptLanguageHeader, ErrInvalidLanguage)
+		if err != nil {
+			return err
+		}
+
+		// the ranges are parsed once instead of once per variant
+		languageRanges = make([]languageRange, len(weightedTokens))
+		for i, weightedToken := range weightedTokens {
+			languageRanges[i] = newLanguageRange(weightedToken)
+		}
+	}
+
+	for i, variant := range variants {
+		if !found || variant.Language == (Language{}) {
+			scores[i].Language = 1
+		} else {
+			scores[i].Language = getQualityValue(getLanguageWeight(languageRanges, variant.Language))
+		}
+	}
+
+	return nil
+}
+
+func scoreCharsets(request *http.Request, variants []Variant, scores []VariantScore) error {
+	// RFC 7231, 5.3.3. Accept-Charset
+	acceptCharsetHeader, found := getListHeader(request.Header, "Accept-Charset")
+
+	var acceptableCharsets []weightedToken
+	if found {
+		var err error
+		if acceptableCharsets, err = parseWeightedTokens(acceptCharsetHeader, ErrInvalidCharset); err != nil {
+			return err
+		}
+	}
+
+	for i, variant := range variants {
+		if !found || len(variant.Charset) == 0 {
+			scores[i].Charset = 1
+		} else {
+			weight, _, _ := getTokenWeight(acceptableCharsets, strings.ToLower(variant.Charset))
+			scores[i].Charset = getQualityValue(weight)
+		}
+	}
+
+	return nil
+}
+
+func scoreEncodings(request *http.Request, variants []Variant, scores []VariantScore) error {
+	// RFC 7231, 5.3.4. Accept-Encoding
+	acceptEncodingHeader, found := getListHeader(request.Header, "Accept-Encoding")
+
+	var acceptableEncodings []weightedToken
+	if found {
+		var err error
+		if acceptableEncodings, err = parseWeightedTokens(acceptEncodingHeader, ErrInvalidEncoding); err != nil {
+			return err
+		}
+
+		for i := range acceptableEncodings {
+			acceptableEncodings[i].token = normalizeEncoding(acceptableEncodings[i].token)
+		}
+	}
+
+	for i, variant := range variants {
+		encoding := EncodingIdentity
+		if len(variant.Encoding) > 0 {
+			encoding = normalizeEncoding(strings.ToLower(variant.Encoding))
+		}
+
+		if !found {
+			scores[i].Encoding = 1
+		} else {
+			weight, _, _ := getEncodingWeight(acceptableEncodings, encoding)
+			scores[i].Encoding = getQualityValue(weight)
+		}
+	}
+
+	return nil
+}
+
+// languageRange is a basic language range of the Accept-Language header parsed for matching.
+type languageRange struct {
+	tag      string
+	weight   uint
+	language Language
+	id       LanguageID
+	parsed   bool // false for the wildcard and the ranges that are not valid language tags
+}
+
+func newLanguageRange(weightedToken weightedToken) languageRange {
+	result := languageRange{tag: weightedToken.token, weight: weightedToken.weight}
+	if language, err := ParseLanguage(weightedToken.token); err == nil {
+		result.language = language
+		result.id = language.ID()
+		result.parsed = true
+	}
+
+	return result
+}
+
+// getLanguageWeight returns the weight of the most specific language range matching the language.
+func getLanguageWeight(languageRanges []languageRange, language Language) uint {
+	// RFC 4647, 3.3.1. Basic Filtering
+	id := language.ID()
+	weight := uint(0)
+	specificity := -1
+	for _, languageRange := range languageRanges {
+		if len(languageRange.tag) > specificity && matchesLanguageRange(language, id, languageRange) {
+			weight = languageRange.weight
+			specificity = len(languageRange.tag)
+		}
+	}
+
+	return weight
+}
+
+// matchesLanguageRange checks whether the language matches the basic language range. For the languages written in
+// several scripts the omitted script is inferred from the region, so that zh-TW matches zh-Hant but never zh-Hans,
+// and the language may omit the region of the range (e.g. zh-Hant matches zh-TW).
+func matchesLanguageRange(language Language, id LanguageID, languageRange languageRange) bool {
+	if languageRange.parsed && languageRange.id == id {
+		return true
+	}
+
+	rangeLanguage := languageRange.language
+	if !languageRange.parsed || !strings.EqualFold(rangeLanguage.Language, language.Language) {
+		return language.Matches(languageRange.tag)
+	}
+
+	script, found := getLikelyScript(language)
+	if !found {
+		return language.Matches(languageRange.tag)
+	}
+
+	if len(rangeLanguage.Script) > 0 || len(rangeLanguage.Region) > 0 {
+		if rangeScript, _ := getLikelyScript(rangeLanguage); !strings.EqualFold(script, rangeScript) {
+			return false
+		}
+	}
+
+	if len(rangeLanguage.Region) > 0 && len(language.Region) > 0 && !strings.EqualFold(rangeLanguage.Region, language.Region) {
+		return false
+	}
+
+	return len(rangeLanguage.Variant) == 0 || strings.EqualFold(rangeLanguage.Variant, language.Variant)
+}
+
+// getLikelyScript returns the script of a language written in several scripts, inferring an omitted script from the
+// region. found is false for the languages written in a single script.
+func getLikelyScript(language Language) (script string, found bool) {
+	if len(language.Language) != 2 {
+		return "", false
+	}
+
+	// normalize the case into a fixed-size buffer to avoid allocating
+	key := [4]byte{language.Language[0] | 0x20, language.Language[1] | 0x20}
+
+	defaultScript, found := lookupTable(scriptLanguages, 2, 4, string(key[:2]))
+	if !found {
+		return "", false
+	}
+
+	if len(language.Script) > 0 {
+		return language.Script, true
+	}
+
+	if len(language.Region) == 2 {
+		key[2], key[3] = language.Region[0]&^0x20, language.Region[1]&^0x20
+		if regionScript, found := lookupTable(scriptLanguageRegions, 4, 4, string(key[:])); found {
+			return regionScript, true
+		}
+	}
+
+	return defaultScript, true
+}
+
+func getQualityValue(weight uint) float64 {
+	return float64(weight) / 1000
+}
@@ -0,0 +1,232 @@
+package contenttype_test
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestNegotiateVariant(t *testing.T) {
+	variants := []contenttype.Variant{
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("en"), Charset: "utf-8"},
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("de"), Charset: "utf-8"},
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("de"), Charset: "utf-8", Encoding: "gzip"},
+		{MediaType: contenttype.NewMediaType("application/json"), Charset: "utf-8"},
+		{MediaType: contenttype.NewMediaType("text/plain"), Language: contenttype.NewLanguage("en"), Charset: "iso-8859-1", Quality: 0.5},
+	}
+
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		result  int
+		score   contenttype.VariantScore
+	}{
+		{
+			name:    "No headers",
+			headers: map[string]string{},
+			result:  0,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 1, Score: 1},
+		},
+		{
+			name:    "Language",
+			headers: map[string]string{"Accept": "text/html", "Accept-Language": "de-CH, de;q=0.9, en;q=0.5"},
+			result:  1,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 0.9, Charset: 1, Encoding: 1, Quality: 1, Score: 0.9},
+		},
+		{
+			name:    "Language and encoding",
+			headers: map[string]string{"Accept-Language": "de, en;q=0.5", "Accept-Encoding": "gzip"},
+			result:  2,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 1, Score: 1},
+		},
+		{
+			name:    "Media type",
+			headers: map[string]string{"Accept": "application/json, text/*;q=0.8"},
+			result:  3,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 1, Score: 1},
+		},
+		{
+			name:    "Multiplied scores",
+			headers: map[string]string{"Accept": "text/html;q=0.5, text/plain", "Accept-Charset": "utf-8;q=0.6, iso-8859-1"},
+			result:  4,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 0.5, Score: 0.5},
+		},
+		{
+			name:    "Lower source quality",
+			headers: map[string]string{"Accept": "text/plain, application/json;q=0.4"},
+			result:  4,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 0.5, Score: 0.5},
+		},
+		{
+			name:    "Excluded charset",
+			headers: map[string]string{"Accept": "text/plain, text/html;q=0.1", "Accept-Charset": "utf-8;q=0, *"},
+			result:  4,
+			score:   contenttype.VariantScore{MediaType: 1, Language: 1, Charset: 1, Encoding: 1, Quality: 0.5, Score: 0.5},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			for key, value := range testCase.headers {
+				request.Header.Set(key, value)
+			}
+
+			result, score, err := contenttype.NegotiateVariant(request, variants)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %v", err, testCase.headers)
+			}
+
+			expected := variants[testCase.result]
+			if !result.MediaType.Equal(expected.MediaType) || result.Language != expected.Language ||
+				result.Charset != expected.Charset || result.Encoding != expected.Encoding {
+				t.Errorf("Invalid variant, got %v, expected %v for %v", result, expected, testCase.headers)
+			}
+
+			if !equalScores(score, testCase.score) {
+				t.Errorf("Invalid score, got %+v, expected %+v for %v", score, testCase.score, testCase.headers)
+			}
+		})
+	}
+}
+
+func TestNegotiateVariantErrors(t *testing.T) {
+	variants := []contenttype.Variant{
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("en")},
+	}
+
+	testCases := []struct {
+		name     string
+		headers  map[string]string
+		variants []contenttype.Variant
+		err      error
+	}{
+		{name: "No variants", headers: map[string]string{}, variants: nil, err: contenttype.ErrNoAvailableVariantGiven},
+		{name: "No acceptable media type", headers: map[string]string{"Accept": "application/json"}, variants: variants, err: contenttype.ErrNoAcceptableVariantFound},
+		{name: "No acceptable language", headers: map[string]string{"Accept-Language": "de"}, variants: variants, err: contenttype.ErrNoAcceptableVariantFound},
+		{name: "Excluded identity", headers: map[string]string{"Accept-Encoding": "*;q=0"}, variants: variants, err: contenttype.ErrNoAcceptableVariantFound},
+		{name: "Invalid Accept", headers: map[string]string{"Accept": "text/"}, variants: variants, err: contenttype.ErrInvalidMediaType},
+		{name: "Invalid Accept-Language", headers: map[string]string{"Accept-Language": "en, @"}, variants: variants, err: contenttype.ErrInvalidLanguage},
+		{name: "Invalid Accept-Charset", headers: map[string]string{"Accept-Charset": "utf-8;q=2"}, variants: variants, err: contenttype.ErrInvalidWeight},
+		{name: "Invalid Accept-Encoding", headers: map[string]string{"Accept-Encoding": "gzip br"}, variants: variants, err: contenttype.ErrInvalidEncoding},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			for key, value := range testCase.headers {
+				request.Header.Set(key, value)
+			}
+
+			_, _, err := contenttype.NegotiateVariant(request, testCase.variants)
+			if err == nil {
+				t.Errorf("Expected an error for %v", testCase.headers)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %v", err, testCase.err, testCase.headers)
+			}
+		})
+	}
+}
+
+func TestNegotiateVariantMultipleHeaders(t *testing.T) {
+	variants := []contenttype.Variant{
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("en")},
+		{MediaType: contenttype.NewMediaType("text/html"), Language: contenttype.NewLanguage("de")},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+	request.Header.Add("Accept-Language", "en;q=0.5")
+	request.Header.Add("Accept-Language", "de")
+
+	result, _, err := contenttype.NegotiateVariant(request, variants)
+	if err != nil {
+		t.Fatalf("Unexpected error \"%v\"", err)
+	}
+
+	if result.Language != variants[1].Language {
+		t.Errorf("Invalid language, got %s, expected %s", result.Language, variants[1].Language)
+	}
+}
+
+func TestNegotiateVariantScripts(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		languages      []string
+		result         string
+	}{
+		{name: "Traditional Chinese for Taiwan", acceptLanguage: "zh-TW", languages: []string{"zh-Hans", "zh-Hant"}, result: "zh-Hant"},
+		{name: "Traditional Chinese for Hong Kong", acceptLanguage: "zh-HK", languages: []string{"zh-Hans-CN", "zh-Hant-TW", "zh-Hant-HK"}, result: "zh-Hant-HK"},
+		{name: "Traditional Chinese with region", acceptLanguage: "zh-TW", languages: []string{"zh-Hans-CN", "zh-Hant-TW"}, result: "zh-Hant-TW"},
+		{name: "Simplified Chinese for China", acceptLanguage: "zh-CN", languages: []string{"zh-Hant", "zh-Hans"}, result: "zh-Hans"},
+		{name: "Script range", acceptLanguage: "zh-Hant", languages: []string{"zh-CN", "zh-TW"}, result: "zh-TW"},
+		{name: "Preferred script", acceptLanguage: "zh-TW, zh;q=0.5", languages: []string{"zh-Hans-CN", "zh-Hant-TW"}, result: "zh-Hant-TW"},
+		{name: "Latin Serbian for Montenegro", acceptLanguage: "sr-ME", languages: []string{"sr-Cyrl", "sr-Latn"}, result: "sr-Latn"},
+		{name: "Cyrillic Serbian", acceptLanguage: "sr-RS", languages: []string{"sr-Latn", "sr-Cyrl"}, result: "sr-Cyrl"},
+		{name: "Arabic Azerbaijani for Iran", acceptLanguage: "az-IR", languages: []string{"az-Latn", "az-Arab"}, result: "az-Arab"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			variants := make([]contenttype.Variant, len(testCase.languages))
+			for i, language := range testCase.languages {
+				variants[i] = contenttype.Variant{Language: contenttype.NewLanguage(language)}
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			request.Header.Set("Accept-Language", testCase.acceptLanguage)
+
+			result, _, err := contenttype.NegotiateVariant(request, variants)
+			if err != nil {
+				t.Fatalf("Unexpected error \"%v\" for %s", err, testCase.acceptLanguage)
+			}
+
+			if result.Language.String() != testCase.result {
+				t.Errorf("Invalid language, got %s, expected %s for %s", result.Language, testCase.result, testCase.acceptLanguage)
+			}
+		})
+	}
+}
+
+func TestNegotiateVariantScriptsErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		languages      []string
+	}{
+		{name: "Only Simplified Chinese", acceptLanguage: "zh-TW", languages: []string{"zh-Hans-CN", "zh-Hans"}},
+		{name: "Only Simplified Chinese without script", acceptLanguage: "zh-Hant", languages: []string{"zh-CN", "zh"}},
+		{name: "Other region", acceptLanguage: "zh-HK", languages: []string{"zh-Hans-CN", "zh-Hant-TW"}},
+		{name: "Only Cyrillic Serbian", acceptLanguage: "sr-Latn", languages: []string{"sr", "sr-Cyrl-RS"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			variants := make([]contenttype.Variant, len(testCase.languages))
+			for i, language := range testCase.languages {
+				variants[i] = contenttype.Variant{Language: contenttype.NewLanguage(language)}
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+			request.Header.Set("Accept-Language", testCase.acceptLanguage)
+
+			if result, _, err := contenttype.NegotiateVariant(request, variants); !errors.Is(err, contenttype.ErrNoAcceptableVariantFound) {
+				t.Errorf("Unexpected error \"%v\" with %s, expected \"%v\" for %s", err, result.Language, contenttype.ErrNoAcceptableVariantFound, testCase.acceptLanguage)
+			}
+		})
+	}
+}
+
+func equalScores(a, b contenttype.VariantScore) bool {
+	const epsilon = 1e-9
+	return math.Abs(a.MediaType-b.MediaType) < epsilon &&
+		math.Abs(a.Language-b.Language) < epsilon &&
+		math.Abs(a.Charset-b.Charset) < epsilon &&
+		math.Abs(a.Encoding-b.Encoding) < epsilon &&
+		math.Abs(a.Quality-b.Quality) < epsilon &&
+		math.Abs(a.Score-b.Score) < epsilon
+}
@@ -0,0 +1,182 @@
+package contenttype
+
+import (
+	"net/http"
+	"strings"
+)
+
+// VersionedMediaType is an available media type that can be served in a range of versions.
+// The media type must not contain the version (e.g. application/vnd.acme.order+json).
+type VersionedMediaType struct {
+	MediaType  MediaType
+	MinVersion uint
+	MaxVersion uint
+	// SubtypeVersion lets media ranges request a version with a vN segment of their subtype
+	// (e.g. application/vnd.acme.v3+json). It is off by default, because registered media types such as
+	// application/vnd.oci.image.manifest.v1+json contain such segments as a part of their name.
+	SubtypeVersion bool
+}
+
+// GetAcceptableVersionedMediaType chooses a media type and its version from available versioned media types
+// according to the Accept header. If the request does not contain the Accept header, every available media type
+// is acceptable in the default version.
+// Returns the most suitable media type, its version and extension parameters, or an error if no type can be selected.
+func GetAcceptableVersionedMediaType(request *http.Request, availableMediaTypes []VersionedMediaType, defaultVersion uint) (MediaType, uint, Parameters, error) {
+	if len(availableMediaTypes) == 0 {
+		return MediaType{}, 0, nil, ErrNoAvailableTypeGiven
+	}
+
+	acceptHeader, found := getListHeader(request.Header, "Accept")
+	if !found {
+		return GetAcceptableVersionedMediaTypeFromHeader("*/*", availableMediaTypes, defaultVersion)
+	}
+
+	return GetAcceptableVersionedMediaTypeFromHeader(acceptHeader, availableMediaTypes, defaultVersion)
+}
+
+// GetAcceptableVersionedMediaTypeFromHeader chooses a media type and its version from available versioned media
+// types according to the specified Accept header value.
+// The version of a media range is taken from its version parameter (e.g. application/vnd.acme.order+json; version=2)
+// or, for the available media types with SubtypeVersion set, from a vN segment of its subtype
+// (e.g. application/vnd.acme.v3+json). Media ranges without a version request the default version.
+// Among equally weighted candidates the highest version is chosen.
+// Returns the most suitable media type, its version and extension parameters, or an error if no type can be selected.
+func GetAcceptableVersionedMediaTypeFromHeader(headerValue string, availableMediaTypes []VersionedMediaType, defaultVersion uint) (MediaType, uint, Parameters, error) {
+	type candidate struct {
+		index               int
+		version             uint
+		mediaType           MediaType
+		extensionParameters string
+		weight              uint
+		order               uint
+	}
+	var candidates []candidate
+
+	err := forEachListElement(headerValue, ErrInvalidMediaRange, func(s string, order uint) (string, error) {
+		acceptableMediaType, weight, extensionParameters, remaining, err := consumeMediaRange(s)
+		if err != nil {
+			return s, err
+		}
+
+		parameterVersion, parameterVersioned, err := extractParameterVersion(&acceptableMediaType)
+		if err != nil {
+			return s, err
+		}
+
+		subtypeRange, subtypeVersion, subtypeVersioned := extractSubtypeVersion(acceptableMediaType)
+
+		for i, availableMediaType := range availableMediaTypes {
+			mediaRange, version := acceptableMediaType, defaultVersion
+			if parameterVersioned {
+				version = parameterVersion
+			} else if subtypeVersioned && availableMediaType.SubtypeVersion {
+				mediaRange, version = subtypeRange, subtypeVersion
+			}
+
+			if version < availableMediaType.MinVersion || version > availableMediaType.MaxVersion ||
+				!compareMediaTypes(mediaRange, availableMediaType.MediaType, false) {
+				continue
+			}
+
+			found := false
+			for j := range candidates {
+				if candidates[j].index == i && candidates[j].version == version {
+					found = true
+					if getPrecedence(mediaRange, candidates[j].mediaType, availableMediaType.MediaType) {
+						candidates[j] = candidate{i, version, mediaRange, extensionParameters, weight, order}
+					}
+				}
+			}
+
+			if !found {
+				candidates = append(candidates, candidate{i, version, mediaRange, extensionParameters, weight, order})
+			}
+		}
+
+		return remaining, nil
+	})
+	if err != nil {
+		return MediaType{}, 0, nil, err
+	}
+
+	resultIndex := -1
+	for i, c := range candidates {
+		if c.weight == 0 {
+			continue
+		}
+
+		if resultIndex == -1 {
+			resultIndex = i
+			continue
+		}
+
+		result := candidates[resultIndex]
+		if c.weight > result.weight ||
+			(c.weight == result.weight && c.version > result.version) ||
+			(c.weight == result.weight && c.version == result.version && c.order < result.order) {
+			resultIndex = i
+		}
+	}
+
+	if resultIndex == -1 {
+		return MediaType{}, 0, nil, ErrNoAcceptableTypeFound
+	}
+
+	result := candidates[resultIndex]
+	return availableMediaTypes[result.index].MediaType, result.version, parseParameters(result.extensionParameters), nil
+}
+
+// extractParameterVersion removes the version parameter from the media range and returns the version.
+func extractParameterVersion(mediaRange *MediaType) (version uint, versioned bool, err error) {
+	value, found := mediaRange.Parameters["version"]
+	if !found {
+		return 0, false, nil
+	}
+
+	if version, versioned = parseVersion(value); !versioned {
+		return 0, false, ErrInvalidVersion
+	}
+
+	delete(mediaRange.Parameters, "version")
+
+	return version, true, nil
+}
+
+// extractSubtypeVersion returns the media range without the vN segment of its subtype and the version.
+func extractSubtypeVersion(mediaRange MediaType) (result MediaType, version uint, versioned bool) {
+	// RFC 6838, 3.2. Vendor Tree
+	name := mediaRange.Subtype
+	suffix := ""
+	if index := strings.LastIndexByte(name, '+'); index != -1 {
+		name, suffix = name[:index], name[index:]
+	}
+
+	segments := strings.Split(name, ".")
+	for i := 1; i < len(segments); i++ { // the first segment is the facet or the name itself
+		if len(segments[i]) < 2 || segments[i][0] != 'v' {
+			continue
+		}
+
+		if version, versioned = parseVersion(segments[i][1:]); versioned {
+			mediaRange.Subtype = strings.Join(append(segments[:i:i], segments[i+1:]...), ".") + suffix
+			return mediaRange, version, true
+		}
+	}
+
+	return MediaType{}, 0, false
+}
+
+func parseVersion(s string) (version uint, parsed bool) {
+	if len(s) == 0 || len(s) > 9 {
+		return 0, false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isDigitChar(s[i]) {
+			return 0, false
+		}
+		version = version*10 + uint(s[i]-'0')
+	}
+
+	return version, true
+}
@@ -0,0 +1,97 @@
+package contenttype_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/elnormous/contenttype"
+)
+
+func TestGetAcceptableVersionedMediaType(t *testing.T) {
+	availableMediaTypes := []contenttype.VersionedMediaType{
+		{MediaType: contenttype.NewMediaType("application/vnd.acme.order+json"), MinVersion: 1, MaxVersion: 3},
+		{MediaType: contenttype.NewMediaType("application/vnd.acme+json"), MinVersion: 2, MaxVersion: 4, SubtypeVersion: true},
+		{MediaType: contenttype.NewMediaType("application/vnd.oci.image.manifest.v1+json"), MinVersion: 2, MaxVersion: 2},
+	}
+
+	testCases := []struct {
+		name                string
+		header              string
+		result              contenttype.MediaType
+		version             uint
+		extensionParameters contenttype.Parameters
+	}{
+		{name: "Empty header", header: "", result: availableMediaTypes[0].MediaType, version: 2, extensionParameters: nil},
+		{name: "Wildcard", header: "*/*", result: availableMediaTypes[0].MediaType, version: 2, extensionParameters: nil},
+		{name: "Version parameter", header: "application/vnd.acme.order+json; version=3", result: availableMediaTypes[0].MediaType, version: 3, extensionParameters: nil},
+		{name: "Version facet", header: "application/vnd.acme.v4+json", result: availableMediaTypes[1].MediaType, version: 4, extensionParameters: nil},
+		{name: "Missing version", header: "application/vnd.acme+json", result: availableMediaTypes[1].MediaType, version: 2, extensionParameters: nil},
+		{name: "Highest compatible version", header: "application/vnd.acme.order+json;version=1,application/vnd.acme.order+json;version=3,application/vnd.acme.order+json;version=5", result: availableMediaTypes[0].MediaType, version: 3, extensionParameters: nil},
+		{name: "Weight before version", header: "application/vnd.acme.v3+json;q=0.5,application/vnd.acme.v2+json", result: availableMediaTypes[1].MediaType, version: 2, extensionParameters: nil},
+		{name: "Excluded version", header: "application/vnd.acme.v3+json;q=0,application/vnd.acme.v4+json;q=0,application/vnd.acme+json;q=0.5", result: availableMediaTypes[1].MediaType, version: 2, extensionParameters: nil},
+		{name: "Extension parameters", header: "application/vnd.acme.order+json;version=1;q=1;ext=a", result: availableMediaTypes[0].MediaType, version: 1, extensionParameters: contenttype.Parameters{"ext": "a"}},
+		{name: "Version segment in registered name", header: "application/vnd.oci.image.manifest.v1+json", result: availableMediaTypes[2].MediaType, version: 2, extensionParameters: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept", testCase.header)
+			}
+
+			result, version, extensionParameters, err := contenttype.GetAcceptableVersionedMediaType(request, availableMediaTypes, 2)
+			if err != nil {
+				t.Errorf("Unexpected error \"%v\" for %s", err, testCase.header)
+			} else if !result.Equal(testCase.result) {
+				t.Errorf("Invalid media type, got %s, expected %s for %s", result, testCase.result, testCase.header)
+			} else if version != testCase.version {
+				t.Errorf("Invalid version, got %d, expected %d for %s", version, testCase.version, testCase.header)
+			} else if !reflect.DeepEqual(extensionParameters, testCase.extensionParameters) {
+				t.Errorf("Wrong extension parameters, got %v, expected %v for %s", extensionParameters, testCase.extensionParameters, testCase.header)
+			}
+		})
+	}
+}
+
+func TestGetAcceptableVersionedMediaTypeErrors(t *testing.T) {
+	availableMediaTypes := []contenttype.VersionedMediaType{
+		{MediaType: contenttype.NewMediaType("application/vnd.acme+json"), MinVersion: 2, MaxVersion: 3, SubtypeVersion: true},
+		{MediaType: contenttype.NewMediaType("application/vnd.acme.order+json"), MinVersion: 1, MaxVersion: 3},
+	}
+
+	testCases := []struct {
+		name                string
+		header              string
+		availableMediaTypes []contenttype.VersionedMediaType
+		err                 error
+	}{
+		{name: "No available type", header: "", availableMediaTypes: nil, err: contenttype.ErrNoAvailableTypeGiven},
+		{name: "Unsupported version", header: "application/vnd.acme.v4+json", availableMediaTypes: availableMediaTypes, err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Unsupported default version", header: "application/vnd.acme+json", availableMediaTypes: availableMediaTypes[:1], err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Version segment without SubtypeVersion", header: "application/vnd.acme.order.v2+json", availableMediaTypes: availableMediaTypes, err: contenttype.ErrNoAcceptableTypeFound},
+		{name: "Invalid version", header: "application/vnd.acme+json;version=a", availableMediaTypes: availableMediaTypes, err: contenttype.ErrInvalidVersion},
+		{name: "Invalid media range", header: "application/vnd.acme+json/", availableMediaTypes: availableMediaTypes, err: contenttype.ErrInvalidMediaRange},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://test.test", nil)
+
+			if len(testCase.header) > 0 {
+				request.Header.Set("Accept", testCase.header)
+			}
+
+			_, _, _, err := contenttype.GetAcceptableVersionedMediaType(request, testCase.availableMediaTypes, 1)
+			if err == nil {
+				t.Errorf("Expected an error for %s", testCase.header)
+			} else if !errors.Is(err, testCase.err) {
+				t.Errorf("Unexpected error \"%v\", expected \"%v\" for %s", err, testCase.err, testCase.header)
+			}
+		})
+	}
+}